@@ -26,6 +26,8 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package v1alpha1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -37,3 +39,120 @@ func (v *Node) GetAccessMode() corev1.PersistentVolumeAccessMode {
 
 	return v.AccessMode
 }
+
+// GetLogin returns whether role may log in, defaults to true
+func (r *Role) GetLogin() bool {
+	if r.Login == nil {
+		return true
+	}
+
+	return *r.Login
+}
+
+// GetConnectionLimit returns configured connection limit, or -1 meaning unlimited
+func (r *Role) GetConnectionLimit() int32 {
+	if r.ConnectionLimit == nil {
+		return -1
+	}
+
+	return *r.ConnectionLimit
+}
+
+// GetCertificateValidity returns validity of generated server certificates, defaults to 90 days
+func (t *TLS) GetCertificateValidity() time.Duration {
+	if t.CertificateValidity == nil {
+		return 90 * 24 * time.Hour
+	}
+
+	return t.CertificateValidity.Duration
+}
+
+// GetRenewBefore returns how long before expiry generated certificates are renewed, defaults to 30 days
+func (t *TLS) GetRenewBefore() time.Duration {
+	if t.RenewBefore == nil {
+		return 30 * 24 * time.Hour
+	}
+
+	return t.RenewBefore.Duration
+}
+
+// GetTool returns configured backup tool, defaults to WAL-G
+func (b *Backup) GetTool() BackupTool {
+	if b.Tool == "" {
+		return BackupToolWALG
+	}
+
+	return b.Tool
+}
+
+// GetSchedule returns base backup schedule, defaults to daily at 02:00
+func (b *Backup) GetSchedule() string {
+	if b.Schedule == "" {
+		return "0 2 * * *"
+	}
+
+	return b.Schedule
+}
+
+// GetRetention returns number of full backups to keep, defaults to 7
+func (b *Backup) GetRetention() int32 {
+	if b.Retention < 1 {
+		return 7
+	}
+
+	return b.Retention
+}
+
+// GetRegion returns region of the bucket, defaults to us-east-1
+func (s *S3Storage) GetRegion() string {
+	if s.Region == "" {
+		return "us-east-1"
+	}
+
+	return s.Region
+}
+
+// GetTarget returns member to back up, defaults to primary
+func (s *PatroniPostgresBackupSpec) GetTarget() BackupTarget {
+	if s.Target == "" {
+		return BackupTargetPrimary
+	}
+
+	return s.Target
+}
+
+// GetTool returns the tool backups were taken with, defaults to WAL-G
+func (r *Recovery) GetTool() BackupTool {
+	if r.Tool == "" {
+		return BackupToolWALG
+	}
+
+	return r.Tool
+}
+
+// GetNamespace returns the source cluster's namespace, defaulting to namespace
+func (c *Clone) GetNamespace(namespace string) string {
+	if c.Namespace == "" {
+		return namespace
+	}
+
+	return c.Namespace
+}
+
+// GetPort returns port of the source cluster, defaults to 5432
+func (s *Standby) GetPort() int32 {
+	if s.Port == 0 {
+		return 5432
+	}
+
+	return s.Port
+}
+
+// GetTool returns the tool WAL was archived with, defaults to WAL-G
+func (a *StandbyArchive) GetTool() BackupTool {
+	if a.Tool == "" {
+		return BackupToolWALG
+	}
+
+	return a.Tool
+}
@@ -67,12 +67,452 @@ type VolumeStatus struct {
 	Capacity resource.Quantity `json:"capacity,omitempty"`
 }
 
+// PgHbaRule defines a pg_hba.conf entry
+// More info: https://www.postgresql.org/docs/current/auth-pg-hba-conf.html
+type PgHbaRule struct {
+	// Type of the connection
+	// +kubebuilder:validation:Enum:=local;host;hostssl;hostnossl
+	// +kubebuilder:default:=host
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Database matched, defaults to all
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// User matched, defaults to all
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Address matched, e.g. a CIDR. Ignored for local type, defaults to all
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Method is the authentication method
+	// +kubebuilder:validation:Enum:=trust;reject;scram-sha-256;md5;password;cert
+	Method string `json:"method"`
+
+	// Options are appended as authentication options
+	// +optional
+	Options string `json:"options,omitempty"`
+}
+
+// Postgresql holds PostgreSQL configuration applied through Patroni dynamic configuration
+type Postgresql struct {
+	// Parameters holds postgresql.conf parameters
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// PgHba lists pg_hba.conf entries. When set, the operator manages pg_hba.conf
+	// through Patroni, and prepends entries required for the cluster to operate.
+	// +optional
+	PgHba []PgHbaRule `json:"pgHba,omitempty"`
+}
+
+// ReplicaService configures a Service for accessing replicas
+type ReplicaService struct {
+	// ServiceType defines replica service type
+	// +kubebuilder:validation:Enum:=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default:=ClusterIP
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// MaxLagBytes excludes replicas lagging behind the leader by more bytes.
+	// Unlimited if not set.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MaxLagBytes *int64 `json:"maxLagBytes,omitempty"`
+}
+
+// Monitoring configures a postgres_exporter sidecar
+type Monitoring struct {
+	// Image of postgres_exporter
+	// +kubebuilder:default:="quay.io/prometheuscommunity/postgres-exporter:v0.17.1"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources of exporter container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// AccessControl controls access to exporter. If undefined, allows access from anywhere
+	// +optional
+	AccessControl []networking.NetworkPolicyPeer `json:"accessControl,omitempty"`
+
+	// ServiceMonitor configures ServiceMonitor created when the monitoring.coreos.com CRD exists
+	// +optional
+	ServiceMonitor ServiceMonitor `json:"serviceMonitor,omitempty"`
+}
+
+// ServiceMonitor configures a Prometheus Operator ServiceMonitor
+type ServiceMonitor struct {
+	// Labels added to ServiceMonitor, e.g. to be selected by Prometheus
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Interval between scrapes, Prometheus' default is used if not set
+	// +kubebuilder:validation:Pattern:=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+}
+
+// TLS configures server TLS
+type TLS struct {
+	// SecretName references a user supplied Secret holding tls.crt, tls.key and ca.crt.
+	// It must cover the cluster's Services, and is not rotated by the operator.
+	// If not set, the operator generates a CA and a server certificate.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// CertificateValidity is the validity of generated server certificates
+	// +kubebuilder:default:="2160h"
+	// +optional
+	CertificateValidity *metav1.Duration `json:"certificateValidity,omitempty"`
+
+	// RenewBefore renews generated certificates this long before they expire
+	// +kubebuilder:default:="720h"
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// TLSStatus holds the state of server certificates
+type TLSStatus struct {
+	// SecretName is the Secret holding the server certificate mounted into members
+	SecretName string `json:"secretName"`
+
+	// NotAfter is the expiry of the server certificate in use
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// ReloadAfter schedules reloading members after the server certificate changed,
+	// once mounted Secrets are updated
+	// +optional
+	ReloadAfter *metav1.Time `json:"reloadAfter,omitempty"`
+
+	// Mounted is set once all members run with the certificate mounted, ssl is turned on afterwards
+	// +optional
+	Mounted bool `json:"mounted,omitempty"`
+}
+
+// BackupTool selects the tool used for WAL archiving and base backups
+type BackupTool string
+
+const (
+	BackupToolWALG       BackupTool = "wal-g"
+	BackupToolPgBackRest BackupTool = "pgbackrest"
+)
+
+// Backup configures continuous WAL archiving and scheduled base backups
+type Backup struct {
+	// Tool used for archiving and base backups, it must be shipped in the image
+	// +kubebuilder:validation:Enum:=wal-g;pgbackrest
+	// +kubebuilder:default:=wal-g
+	// +optional
+	Tool BackupTool `json:"tool,omitempty"`
+
+	// S3 configures an S3-compatible storage to store backups in
+	S3 S3Storage `json:"s3"`
+
+	// Schedule of base backups in cron format, in UTC
+	// +kubebuilder:default:="0 2 * * *"
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Retention is the number of full base backups to keep, along with WAL needed to restore them
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=7
+	// +optional
+	Retention int32 `json:"retention,omitempty"`
+}
+
+// S3Storage configures an S3-compatible storage
+type S3Storage struct {
+	// Endpoint URL, e.g. https://minio.minio.svc:9000
+	// +kubebuilder:validation:Pattern:=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// Bucket to store backups in
+	// +kubebuilder:validation:MinLength:=1
+	Bucket string `json:"bucket"`
+
+	// Path within bucket, defaults to <namespace>/<name>. Each major version is stored in a subdirectory.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Region of the bucket
+	// +kubebuilder:default:=us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecret references a Secret holding AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+	// +kubebuilder:validation:MinLength:=1
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// BackupStatus holds the state of base backups and WAL archiving
+type BackupStatus struct {
+	// LastBackup is the completion time of the last successful base backup
+	// +optional
+	LastBackup *metav1.Time `json:"lastBackup,omitempty"`
+
+	// LastFailedBackup is the time of the last failed base backup
+	// +optional
+	LastFailedBackup *metav1.Time `json:"lastFailedBackup,omitempty"`
+
+	// NextBackup is the time of the next scheduled base backup
+	// +optional
+	NextBackup *metav1.Time `json:"nextBackup,omitempty"`
+
+	// ArchivedCount is the number of WAL files archived successfully
+	// +optional
+	ArchivedCount int64 `json:"archivedCount,omitempty"`
+
+	// LastArchivedWAL is the name of the last WAL file archived successfully
+	// +optional
+	LastArchivedWAL string `json:"lastArchivedWAL,omitempty"`
+
+	// LastArchivedTime is the time of the last successful archival
+	// +optional
+	LastArchivedTime *metav1.Time `json:"lastArchivedTime,omitempty"`
+
+	// FailedCount is the number of failed archival attempts
+	// +optional
+	FailedCount int64 `json:"failedCount,omitempty"`
+
+	// LastFailedWAL is the name of the WAL file of the last failed archival
+	// +optional
+	LastFailedWAL string `json:"lastFailedWAL,omitempty"`
+
+	// LastFailedTime is the time of the last failed archival
+	// +optional
+	LastFailedTime *metav1.Time `json:"lastFailedTime,omitempty"`
+}
+
+// Bootstrap configures how a new cluster is created instead of running initdb, only one method may be given
+// +kubebuilder:validation:MaxProperties:=1
+type Bootstrap struct {
+	// Recovery restores the cluster from a backup repository
+	// +optional
+	Recovery *Recovery `json:"recovery,omitempty"`
+
+	// Clone copies data of a running cluster
+	// +optional
+	Clone *Clone `json:"clone,omitempty"`
+}
+
+// Clone takes a base backup of a running PatroniPostgres cluster with its replication user.
+// The source cluster's NetworkPolicy is opened for the cloning Job while bootstrapping.
+type Clone struct {
+	// Cluster is the name of the source PatroniPostgres, it must be of the same version
+	Cluster string `json:"cluster"`
+
+	// Namespace of the source cluster, defaults to the namespace of the new cluster
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Recovery restores a base backup, then recovers it from archived WAL
+type Recovery struct {
+	// Tool the backups were taken with, it must be shipped in the image
+	// +kubebuilder:validation:Enum:=wal-g;pgbackrest
+	// +kubebuilder:default:=wal-g
+	// +optional
+	Tool BackupTool `json:"tool,omitempty"`
+
+	// S3 configures the storage holding backups. Path must be set to the source cluster's s3.path,
+	// unless it had the same namespace and name.
+	S3 S3Storage `json:"s3"`
+
+	// Backup is the name of the base backup to restore, defaults to the latest one
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9_.-]+$`
+	// +optional
+	Backup string `json:"backup,omitempty"`
+
+	// Target to recover up to, defaults to the end of archived WAL
+	// +optional
+	Target *RecoveryTarget `json:"target,omitempty"`
+}
+
+// RecoveryTarget selects the point recovery stops at, only one may be given
+// +kubebuilder:validation:MaxProperties:=1
+type RecoveryTarget struct {
+	// Time to recover up to
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// LSN to recover up to
+	// +kubebuilder:validation:Pattern:=`^[0-9A-Fa-f]{1,8}/[0-9A-Fa-f]{1,8}$`
+	// +optional
+	LSN string `json:"lsn,omitempty"`
+
+	// Name of a restore point created with pg_create_restore_point() to recover up to
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9_.-]+$`
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// Standby configures Patroni's standby_cluster section. The standby leader streams from host, or
+// fetches WAL from archive, at least one of them must be set.
+type Standby struct {
+	// Host of the source cluster's primary, connected with the replication user
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Port of the source cluster's primary
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +kubebuilder:default:=5432
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// PrimarySlotName is the replication slot on the source cluster to stream from
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9_]+$`
+	// +optional
+	PrimarySlotName string `json:"primarySlotName,omitempty"`
+
+	// Archive holds WAL archived by the source cluster
+	// +optional
+	Archive *StandbyArchive `json:"archive,omitempty"`
+}
+
+// StandbyArchive is a backup repository WAL is fetched from
+type StandbyArchive struct {
+	// Tool the WAL was archived with, it must be shipped in the image
+	// +kubebuilder:validation:Enum:=wal-g;pgbackrest
+	// +kubebuilder:default:=wal-g
+	// +optional
+	Tool BackupTool `json:"tool,omitempty"`
+
+	// S3 configures the storage holding the archive. Path must be set to the source cluster's s3.path,
+	// unless it had the same namespace and name.
+	S3 S3Storage `json:"s3"`
+}
+
+// Switchover requests a leader change
+type Switchover struct {
+	// Candidate is the index of the member (node) to become the leader
+	// +kubebuilder:validation:Minimum:=0
+	Candidate int `json:"candidate"`
+
+	// ScheduledAt schedules the switchover to given time. Performed immediately if not set.
+	// +optional
+	ScheduledAt *metav1.Time `json:"scheduledAt,omitempty"`
+}
+
+// SwitchoverPhase represents switchover progress
+type SwitchoverPhase string
+
+const (
+	SwitchoverPhaseScheduled SwitchoverPhase = "Scheduled"
+	SwitchoverPhaseSucceeded SwitchoverPhase = "Succeeded"
+	SwitchoverPhaseFailed    SwitchoverPhase = "Failed"
+	SwitchoverPhaseCancelled SwitchoverPhase = "Cancelled"
+)
+
+// SwitchoverStatus holds the state of the last requested switchover
+type SwitchoverStatus struct {
+	// Candidate is the index of the requested member
+	Candidate int `json:"candidate"`
+
+	// ScheduledAt is the requested switchover time
+	// +optional
+	ScheduledAt *metav1.Time `json:"scheduledAt,omitempty"`
+
+	// Phase of the switchover
+	Phase SwitchoverPhase `json:"phase"`
+
+	// Message holds details, like failure reason
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the time of last phase change
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// Role defines a PostgreSQL role managed by the operator
+type Role struct {
+	// Name of the role. Its password is stored in a Secret named <cluster>-role-<name>,
+	// with underscores replaced by dashes.
+	// +kubebuilder:validation:Pattern:=`^[a-z][a-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength:=63
+	Name string `json:"name"`
+
+	// Login allows the role to log in. Defaults to true.
+	// +optional
+	Login *bool `json:"login,omitempty"`
+
+	// ConnectionLimit limits concurrent connections of the role. Unlimited if not set.
+	// +kubebuilder:validation:Minimum:=-1
+	// +optional
+	ConnectionLimit *int32 `json:"connectionLimit,omitempty"`
+
+	// CreateDB allows the role to create databases
+	// +optional
+	CreateDB bool `json:"createDB,omitempty"`
+
+	// CreateRole allows the role to create roles
+	// +optional
+	CreateRole bool `json:"createRole,omitempty"`
+
+	// MemberOf lists roles this role is member of
+	// +optional
+	MemberOf []string `json:"memberOf,omitempty"`
+
+	// CertificateAuthentication lets the role log in with a client certificate over TLS.
+	// The certificate is issued into the role's Secret, and TLS is enabled implicitly.
+	// +optional
+	CertificateAuthentication bool `json:"certificateAuthentication,omitempty"`
+}
+
+// Database defines a PostgreSQL database managed by the operator
+type Database struct {
+	// Name of the database
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	Name string `json:"name"`
+
+	// Owner of the database, defaults to the superuser
+	// +optional
+	Owner string `json:"owner,omitempty"`
+}
+
+// DropPolicy defines what happens with roles and databases removed from spec
+type DropPolicy string
+
+const (
+	DropPolicyRetain DropPolicy = "Retain"
+	DropPolicyDrop   DropPolicy = "Drop"
+)
+
+// MemberStatus holds a member's state as reported by Patroni
+type MemberStatus struct {
+	// Name of the member (POD)
+	Name string `json:"name"`
+
+	// Role of the member: leader, standby_leader, sync_standby or replica
+	Role string `json:"role"`
+
+	// State of the member, e.g. running or streaming
+	State string `json:"state"`
+
+	// Timeline of the member
+	Timeline int64 `json:"timeline,omitempty"`
+
+	// Lag holds replication lag in bytes, unset if unknown
+	Lag *int64 `json:"lag,omitempty"`
+
+	// PendingRestart is set if member needs a restart to apply configuration changes
+	PendingRestart bool `json:"pendingRestart,omitempty"`
+}
+
 // PatroniPostgresSpec defines the desired state of PatroniPostgres
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.standby) || !has(self.standby)",message="standby can only be set on creation"
 type PatroniPostgresSpec struct {
 	// Ignore marks this instance to be ignored by the operator
 	Ignore bool `json:"ignore,omitempty"`
 
-	// +kubebuilder:validation:Enum:=13;15;17
+	// Version is the PostgreSQL major version, must be listed in the operator's image catalog
+	// +kubebuilder:validation:Minimum:=10
 	Version int `json:"version"`
 
 	// Nodes holds nodes's desired configuration.
@@ -138,15 +578,86 @@ type PatroniPostgresSpec struct {
 	// NetworkPolicy object. Useful for opening ports for ExtraContainers.
 	// +optional
 	AdditionalNetworkPolicyIngress []networking.NetworkPolicyIngressRule `json:"additionalNetworkPolicyIngress,omitempty"`
+
+	// ReplicaService if set, creates a <name>-repl Service selecting replicas which are streaming
+	// from the leader, and are not lagging behind more than allowed.
+	// +optional
+	ReplicaService *ReplicaService `json:"replicaService,omitempty"`
+
+	// Monitoring if set, adds a postgres_exporter sidecar using a dedicated monitoring role,
+	// and creates a <name>-metrics Service and a ServiceMonitor for it.
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// TLS if set, enables TLS for PostgreSQL connections, including replication.
+	// Enabled with defaults if a role uses certificate authentication.
+	// +optional
+	TLS *TLS `json:"tls,omitempty"`
+
+	// Backup if set, archives WAL continuously, and takes base backups on schedule
+	// +optional
+	Backup *Backup `json:"backup,omitempty"`
+
+	// Bootstrap if set, creates the cluster from existing data instead of running initdb. Only used on creation.
+	// +optional
+	Bootstrap *Bootstrap `json:"bootstrap,omitempty"`
+
+	// Standby if set on creation, makes the cluster a Patroni standby cluster replicating from another one.
+	// Removing it promotes the cluster. Takes precedence over bootstrap.
+	// +optional
+	Standby *Standby `json:"standby,omitempty"`
+
+	// CloneAllowedNamespaces lists namespaces, including the cluster's own, clusters in which may clone this
+	// one with spec.bootstrap.clone. Others are refused.
+	// +optional
+	CloneAllowedNamespaces []string `json:"cloneAllowedNamespaces,omitempty"`
+
+	// Switchover requests a switchover to given member, immediately or at a scheduled time.
+	// A new switchover is performed whenever this changes.
+	// Removing it cancels a scheduled switchover.
+	// +optional
+	Switchover *Switchover `json:"switchover,omitempty"`
+
+	// Postgresql holds PostgreSQL configuration
+	// +optional
+	Postgresql Postgresql `json:"postgresql,omitempty"`
+
+	// Roles lists PostgreSQL roles to be managed by the operator
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Roles []Role `json:"roles,omitempty"`
+
+	// Databases lists PostgreSQL databases to be managed by the operator
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Databases []Database `json:"databases,omitempty"`
+
+	// UpgradeAbortAfterFailures aborts an upgrade automatically after given number of
+	// failed upgrade jobs. Zero disables automatic abort.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	UpgradeAbortAfterFailures int32 `json:"upgradeAbortAfterFailures,omitempty"`
+
+	// DropPolicy controls whether roles and databases removed from spec are
+	// dropped (Drop), or left intact (Retain) in PostgreSQL
+	// +kubebuilder:validation:Enum:=Retain;Drop
+	// +kubebuilder:default:=Retain
+	// +optional
+	DropPolicy DropPolicy `json:"dropPolicy,omitempty"`
 }
 
 // PatroniPostgresState represents overall cluster state
 type PatroniPostgresState string
 
 const (
+	PatroniPostgresStateBootstrapping              PatroniPostgresState = "bootstrapping"
 	PatroniPostgresStateScaling                    PatroniPostgresState = "scaling"
 	PatroniPostgresStateReady                      PatroniPostgresState = "ready"
+	PatroniPostgresStateUpdating                   PatroniPostgresState = "updating"
 	PatroniPostgresStateUpgradePreupgrade          PatroniPostgresState = "upgrade-preupgrade"
+	PatroniPostgresStateUpgradePreflight           PatroniPostgresState = "upgrade-preflight"
 	PatroniPostgresStateUpgradePreupgradeScaleDown PatroniPostgresState = "upgrade-preupgrade-scaledown"
 	PatroniPostgresStateUpgradePreupgradeSync      PatroniPostgresState = "upgrade-preupgrade-sync"
 	PatroniPostgresStateUpgradeScaleDown           PatroniPostgresState = "upgrade-scaledown"
@@ -154,6 +665,32 @@ const (
 	PatroniPostgresStateUpgradeSecondaries         PatroniPostgresState = "upgrade-secondaries"
 	PatroniPostgresStateUpgradePrimaryMove         PatroniPostgresState = "upgrade-primary-move"
 	PatroniPostgresStateUpgradePostupgrade         PatroniPostgresState = "upgrade-postupgrade"
+	PatroniPostgresStateUpgradeAbortScaleDown      PatroniPostgresState = "upgrade-abort-scaledown"
+	PatroniPostgresStateUpgradeAbortRestore        PatroniPostgresState = "upgrade-abort-restore"
+)
+
+// Condition types of PatroniPostgres
+const (
+	// ConditionReady is true when all members are up and the cluster is ready
+	ConditionReady = "Ready"
+
+	// ConditionProgressing is true while the cluster is being scaled, updated or upgraded
+	ConditionProgressing = "Progressing"
+
+	// ConditionUpgrading is true while a major upgrade is in progress
+	ConditionUpgrading = "Upgrading"
+
+	// ConditionDegraded is true when some members are missing or not healthy
+	ConditionDegraded = "Degraded"
+
+	// ConditionReconcileError is true when the last reconciliation failed
+	ConditionReconcileError = "ReconcileError"
+)
+
+const (
+	// AbortUpgradeAnnotation when set on a PatroniPostgres object aborts the upgrade in progress,
+	// if the primary has not been upgraded yet
+	AbortUpgradeAnnotation = "patronipostgres.kwebs.cloud/abort-upgrade"
 )
 
 // PatroniPostgresStatus defines the observed state of PatroniPostgres
@@ -170,11 +707,79 @@ type PatroniPostgresStatus struct {
 	// State represents cluster state
 	State PatroniPostgresState `json:"state"`
 
-	// UpgradeVersion represents upgrade target version
+	// ObservedGeneration is the generation last reconciled successfully
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of cluster state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Members holds members' state as reported by Patroni
+	Members []MemberStatus `json:"members,omitempty"`
+
+	// Leader holds current leader's name
+	Leader string `json:"leader,omitempty"`
+
+	// MaxLag holds the highest replication lag in bytes among replicas
+	MaxLag *int64 `json:"maxLag,omitempty"`
+
+	// Switchover holds the state of the last requested switchover
+	Switchover *SwitchoverStatus `json:"switchover,omitempty"`
+
+	// TLS holds the state of server certificates
+	TLS *TLSStatus `json:"tls,omitempty"`
+
+	// Backup holds the state of backups. Set once archiving is configured in Patroni.
+	Backup *BackupStatus `json:"backup,omitempty"`
+
+	// Standby is set while the cluster is a standby cluster, until it is promoted
+	Standby bool `json:"standby,omitempty"`
+
+	// UpgradeVersion represents target version of the upgrade in progress
 	UpgradeVersion int `json:"upgradeVersion,omitempty"`
 
-	// UpgradeVersions holds available versions to upgrade to
+	// UpgradePath lists versions to upgrade through to reach the requested version,
+	// including the requested version. Upgrades are performed one at a time.
+	UpgradePath []int `json:"upgradePath,omitempty"`
+
+	// UpgradeFailures counts failed upgrade jobs of the upgrade in progress
+	UpgradeFailures int32 `json:"upgradeFailures,omitempty"`
+
+	// UpgradeStepStartTime holds the time the current upgrade step was started
+	UpgradeStepStartTime *metav1.Time `json:"upgradeStepStartTime,omitempty"`
+
+	// UpgradePreflightError holds the reason of the last upgrade cancelled by preflight checks
+	UpgradePreflightError string `json:"upgradePreflightError,omitempty"`
+
+	// UpgradeAbortedVersion holds the requested version of the last aborted or cancelled upgrade.
+	// Upgrade to this version is not retried until spec.version is reverted to the current version.
+	UpgradeAbortedVersion int `json:"upgradeAbortedVersion,omitempty"`
+
+	// UpgradeVersions holds available versions to upgrade to, possibly through multiple upgrades
 	UpgradeVersions []int `json:"upgradeVersions,omitempty"`
+
+	// ManagedRoles lists roles created by the operator
+	ManagedRoles []string `json:"managedRoles,omitempty"`
+
+	// ManagedDatabases lists databases created by the operator
+	ManagedDatabases []string `json:"managedDatabases,omitempty"`
+
+	// ManagedParameters lists parameters set by the operator in Patroni dynamic configuration
+	ManagedParameters []string `json:"managedParameters,omitempty"`
+
+	// PreviousParameters holds JSON encoded values of managed parameters, as they were set in
+	// Patroni dynamic configuration before being managed. They are restored once no longer managed.
+	PreviousParameters map[string]string `json:"previousParameters,omitempty"`
+
+	// PgHbaManaged is set when pg_hba.conf is managed by the operator
+	PgHbaManaged bool `json:"pgHbaManaged,omitempty"`
+
+	// PendingRestart lists parameters whose change requires a restart on any member
+	PendingRestart []string `json:"pendingRestart,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -183,6 +788,8 @@ type PatroniPostgresStatus struct {
 //+kubebuilder:printcolumn:JSONPath=.status.ready,description="Ready replicas",name=Ready,type=integer
 //+kubebuilder:printcolumn:JSONPath=.status.state,description="Cluster state",name=State,type=string
 //+kubebuilder:printcolumn:JSONPath=.status.upgradeVersions,description="Available versions to upgrade to",name=UVer,type=string
+//+kubebuilder:printcolumn:JSONPath=.status.leader,description="Current leader",name=Leader,type=string
+//+kubebuilder:printcolumn:JSONPath=.status.maxLag,description="Highest replication lag in bytes",name=Lag,type=integer
 
 // PatroniPostgres is the Schema for the patronipostgres API
 type PatroniPostgres struct {
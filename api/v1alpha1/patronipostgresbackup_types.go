@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupTarget selects the member a base backup is taken of
+type BackupTarget string
+
+const (
+	// BackupTargetPrimary backs up the leader
+	BackupTargetPrimary BackupTarget = "primary"
+
+	// BackupTargetPreferReplica backs up the least lagging streaming replica, or the leader if there is none
+	BackupTargetPreferReplica BackupTarget = "prefer-replica"
+)
+
+// BackupPhase is the phase of a base backup
+type BackupPhase string
+
+const (
+	BackupPhasePending   BackupPhase = "Pending"
+	BackupPhaseRunning   BackupPhase = "Running"
+	BackupPhaseCompleted BackupPhase = "Completed"
+	BackupPhaseFailed    BackupPhase = "Failed"
+)
+
+// PatroniPostgresBackupSpec defines the desired state of PatroniPostgresBackup
+type PatroniPostgresBackupSpec struct {
+	// Cluster is the name of the PatroniPostgres in the same namespace to back up, its spec.backup must be set
+	// +kubebuilder:validation:MinLength:=1
+	Cluster string `json:"cluster"`
+
+	// Target selects the member to back up. Replicas are backed up with wal-g only.
+	// +kubebuilder:validation:Enum:=primary;prefer-replica
+	// +kubebuilder:default:=primary
+	// +optional
+	Target BackupTarget `json:"target,omitempty"`
+}
+
+// PatroniPostgresBackupStatus defines the observed state of PatroniPostgresBackup
+type PatroniPostgresBackupStatus struct {
+	// Phase of the backup
+	Phase BackupPhase `json:"phase,omitempty"`
+
+	// Member is the name of the member backed up
+	Member string `json:"member,omitempty"`
+
+	// StartTime is when the backup Job was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the backup Job finished
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Duration of the backup
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Repository is the path of the repository within the bucket, which is specific to the major version
+	Repository string `json:"repository,omitempty"`
+
+	// BackupName is the name of the base backup in the repository
+	BackupName string `json:"backupName,omitempty"`
+
+	// StartLSN is the WAL position the base backup starts at
+	StartLSN string `json:"startLSN,omitempty"`
+
+	// StopLSN is the WAL position the base backup is consistent at
+	StopLSN string `json:"stopLSN,omitempty"`
+
+	// Size of the base backup stored in the repository, in bytes
+	Size int64 `json:"size,omitempty"`
+
+	// Error holds the reason of failure
+	Error string `json:"error,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:JSONPath=.spec.cluster,description="Cluster",name=Cluster,type=string
+//+kubebuilder:printcolumn:JSONPath=.status.phase,description="Phase",name=Phase,type=string
+//+kubebuilder:printcolumn:JSONPath=.status.member,description="Member backed up",name=Member,type=string
+//+kubebuilder:printcolumn:JSONPath=.status.backupName,description="Name in the repository",name=Backup,type=string,priority=1
+//+kubebuilder:printcolumn:JSONPath=.status.size,description="Size in bytes",name=Size,type=integer
+//+kubebuilder:printcolumn:JSONPath=.metadata.creationTimestamp,name=Age,type=date
+
+// PatroniPostgresBackup is the Schema for the patronipostgresbackups API
+type PatroniPostgresBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PatroniPostgresBackupSpec   `json:"spec,omitempty"`
+	Status PatroniPostgresBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PatroniPostgresBackupList contains a list of PatroniPostgresBackup
+type PatroniPostgresBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PatroniPostgresBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PatroniPostgresBackup{}, &PatroniPostgresBackupList{})
+}
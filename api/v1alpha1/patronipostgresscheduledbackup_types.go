@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PatroniPostgresScheduledBackupSpec defines the desired state of PatroniPostgresScheduledBackup
+type PatroniPostgresScheduledBackupSpec struct {
+	// Cluster is the name of the PatroniPostgres in the same namespace to back up, its spec.backup must be set
+	// +kubebuilder:validation:MinLength:=1
+	Cluster string `json:"cluster"`
+
+	// Schedule of backups in cron format, in UTC
+	// +kubebuilder:validation:MinLength:=1
+	Schedule string `json:"schedule"`
+
+	// Target selects the member to back up. Replicas are backed up with wal-g only.
+	// +kubebuilder:validation:Enum:=primary;prefer-replica
+	// +kubebuilder:default:=primary
+	// +optional
+	Target BackupTarget `json:"target,omitempty"`
+
+	// Suspend stops creating backups
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// PatroniPostgresScheduledBackupStatus defines the observed state of PatroniPostgresScheduledBackup
+type PatroniPostgresScheduledBackupStatus struct {
+	// LastScheduleTime is when the last backup was created
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is when the next backup is created
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// LastBackup is the name of the last PatroniPostgresBackup created
+	LastBackup string `json:"lastBackup,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:JSONPath=.spec.cluster,description="Cluster",name=Cluster,type=string
+//+kubebuilder:printcolumn:JSONPath=.spec.schedule,description="Schedule",name=Schedule,type=string
+//+kubebuilder:printcolumn:JSONPath=.status.lastBackup,description="Last backup created",name=Last,type=string
+//+kubebuilder:printcolumn:JSONPath=.status.nextScheduleTime,description="Next backup",name=Next,type=date
+
+// PatroniPostgresScheduledBackup is the Schema for the patronipostgresscheduledbackups API
+type PatroniPostgresScheduledBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PatroniPostgresScheduledBackupSpec   `json:"spec,omitempty"`
+	Status PatroniPostgresScheduledBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PatroniPostgresScheduledBackupList contains a list of PatroniPostgresScheduledBackup
+type PatroniPostgresScheduledBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PatroniPostgresScheduledBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PatroniPostgresScheduledBackup{}, &PatroniPostgresScheduledBackupList{})
+}
@@ -40,6 +40,8 @@ var (
 	dbuser     = flag.String("dbuser", "postgres", "postgres user")
 	dbpassword = flag.String("dbpassword", "", "postgres password")
 	dbname     = flag.String("dbname", "postgres", "postgres database name")
+	sslmode    = flag.String("sslmode", "disable", "postgres sslmode")
+	sslroot    = flag.String("sslrootcert", "", "CA certificate to verify postgres server with")
 	mode       = flag.String("mode", "", "operation mode")
 )
 
@@ -63,12 +65,17 @@ func (d connectwithdbname) apply(c *pgx.ConnConfig) {
 	c.Database = string(d)
 }
 
-// connectdb connects to a database within connectTimeout
+// connectdb connects to a database within connectTimeout. With sslmode=verify-full, server is
+// verified against dbhost, even when connecting to a member by its address.
 func connectdb(ctx context.Context, options ...connectoption) (*pgx.Conn, error) {
-	config, err := pgx.ParseConfig(
-		fmt.Sprintf(
-			"host=%s port=%d user=%s password=%s database=%s sslmode=disable",
-			*dbhost, *dbport, *dbuser, *dbpassword, *dbname))
+	connstring := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s database=%s sslmode=%s",
+		*dbhost, *dbport, *dbuser, *dbpassword, *dbname, *sslmode)
+	if *sslroot != "" {
+		connstring += " sslrootcert=" + *sslroot
+	}
+
+	config, err := pgx.ParseConfig(connstring)
 	if err != nil {
 		return nil, err
 	}
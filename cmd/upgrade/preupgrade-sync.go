@@ -230,11 +230,36 @@ func pauseCluster(ctx context.Context) (err error) {
 	return
 }
 
+// checkPreparedTransactions fails if prepared transactions were started after preupgrade, as
+// clients cannot finish them on the maintenance port
+func checkPreparedTransactions(ctx context.Context) (err error) {
+	conn, err := connectprimarydb(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close(ctx)
+
+	gids, err := readPreparedTransactions(ctx, conn)
+	if err != nil {
+		return
+	}
+
+	if len(gids) > 0 {
+		err = fmt.Errorf("prepared transactions pending: %s", strings.Join(gids, ", "))
+	}
+
+	return
+}
+
 func preupgradesyncfn(ctx context.Context) (err error) {
 	for i := 0; i < *clustersize; i++ {
 		memberNames = append(memberNames, fmt.Sprintf("%s-%d", *clustername, i))
 	}
 
+	if err = checkPreparedTransactions(ctx); err != nil {
+		return
+	}
+
 	if err = syncRestorePoints(ctx); err != nil {
 		return
 	}
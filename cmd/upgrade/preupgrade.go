@@ -27,13 +27,23 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"io"
-	"os"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/namsral/flag"
 
 	upgradecommon "github.com/k-web-s/patroni-postgres-operator/private/upgrade/common"
 )
 
+const (
+	maxReportedPreparedTransactions = 16
+)
+
+var (
+	preparedXactsTimeout = flag.Duration(upgradecommon.PreparedXactsTimeoutFlag, 5*time.Minute, "Time to wait for prepared transactions to finish")
+)
+
 func preupgradefn(ctx context.Context) (err error) {
 	dbconn, err := connectdb(ctx)
 	if err != nil {
@@ -51,27 +61,54 @@ func preupgradefn(ctx context.Context) (err error) {
 		return
 	}
 
-	if err = dbconn.QueryRow(ctx, `SELECT
-		pg_catalog.current_setting('data_checksums')::bool,
-		pg_catalog.current_setting('max_prepared_transactions')::int`).
-		Scan(&cfg.DataChecksums, &cfg.MaxPreparedTransactions); err != nil {
+	if err = dbconn.QueryRow(ctx, "SELECT pg_catalog.current_setting('data_checksums')::bool").Scan(&cfg.DataChecksums); err != nil {
 		return
 	}
 
-	var out []byte
-	if out, err = json.Marshal(&cfg); err != nil {
+	if cfg.PreparedTransactions, err = waitPreparedTransactions(ctx, dbconn, *preparedXactsTimeout); err != nil {
 		return
 	}
 
-	var n int
-	n, err = os.Stdout.Write(out)
+	// keep result within termination message size limit
+	if len(cfg.PreparedTransactions) > maxReportedPreparedTransactions {
+		cfg.PreparedTransactions = append(cfg.PreparedTransactions[:maxReportedPreparedTransactions], "...")
+	}
+
+	err = writeResult(&cfg)
+
+	return
+}
+
+// readPreparedTransactions returns GIDs of prepared transactions
+func readPreparedTransactions(ctx context.Context, conn *pgx.Conn) (gids []string, err error) {
+	rows, err := conn.Query(ctx, "SELECT gid FROM pg_catalog.pg_prepared_xacts ORDER BY prepared")
 	if err != nil {
 		return
 	}
 
-	if n < len(out) {
-		err = io.ErrShortWrite
-	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
 
-	return
+// waitPreparedTransactions waits up to timeout for prepared transactions to be finished.
+// Returns GIDs of prepared transactions still pending.
+func waitPreparedTransactions(ctx context.Context, conn *pgx.Conn, timeout time.Duration) (gids []string, err error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if gids, err = readPreparedTransactions(ctx, conn); err != nil || len(gids) == 0 {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			return
+		}
+
+		log.Printf("Waiting for %d prepared transactions to finish", len(gids))
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
 }
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/patroni"
+)
+
+// patchStatus updates conditions, and patches status. If reconciliation failed, only
+// upgrade failures are patched, other changes of status are dropped, thus conditions are
+// computed from the original status. The instance patched is returned.
+func (r *PatroniPostgresReconciler) patchStatus(ctx context.Context, original, p *v1alpha1.PatroniPostgres, reconcileErr error) (*v1alpha1.PatroniPostgres, error) {
+	if reconcileErr == nil {
+		setConditions(p, nil)
+		p.Status.ObservedGeneration = p.Generation
+
+		if err := r.Status().Patch(ctx, p, client.MergeFrom(original)); err != nil {
+			return nil, err
+		}
+
+		r.recordStateChange(original, p)
+
+		return p, nil
+	}
+
+	failed := original.DeepCopy()
+	failed.Status.UpgradeFailures = p.Status.UpgradeFailures
+	setConditions(failed, reconcileErr)
+
+	if err := r.Status().Patch(ctx, failed, client.MergeFrom(original)); err != nil {
+		return nil, err
+	}
+
+	return failed, nil
+}
+
+// recordStateChange emits Events on readiness changes
+func (r *PatroniPostgresReconciler) recordStateChange(original, p *v1alpha1.PatroniPostgres) {
+	if original.Status.State == p.Status.State {
+		return
+	}
+
+	switch {
+	case p.Status.State == v1alpha1.PatroniPostgresStateReady:
+		r.Recorder.Eventf(p, corev1.EventTypeNormal, "Ready", "Cluster is ready at version %d with %d members", p.Status.Version, len(p.Spec.Nodes))
+	case original.Status.State == v1alpha1.PatroniPostgresStateReady && p.Status.State == v1alpha1.PatroniPostgresStateScaling:
+		r.Recorder.Eventf(p, corev1.EventTypeWarning, "NotReady", "Cluster is not ready, waiting for members")
+	}
+}
+
+// setConditions computes conditions from status
+func setConditions(p *v1alpha1.PatroniPostgres, reconcileErr error) {
+	state := stateReason(p.Status.State)
+
+	set := func(conditionType string, status bool, reason, message string) {
+		condition := metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: p.Generation,
+			Reason:             reason,
+			Message:            message,
+		}
+		if status {
+			condition.Status = metav1.ConditionTrue
+		}
+
+		meta.SetStatusCondition(&p.Status.Conditions, condition)
+	}
+
+	if reconcileErr != nil {
+		set(v1alpha1.ConditionReconcileError, true, "ReconcileFailed", reconcileErr.Error())
+	} else {
+		set(v1alpha1.ConditionReconcileError, false, "ReconcileSucceeded", "")
+	}
+
+	ready := p.Status.State == v1alpha1.PatroniPostgresStateReady
+	set(v1alpha1.ConditionReady, ready, state, fmt.Sprintf("Cluster is %s, %d/%d members ready", p.Status.State, p.Status.Ready, len(p.Spec.Nodes)))
+
+	set(v1alpha1.ConditionProgressing, !ready && p.Status.State != "", state, fmt.Sprintf("Cluster is %s", p.Status.State))
+
+	switch {
+	case p.Status.UpgradeVersion != 0:
+		set(v1alpha1.ConditionUpgrading, true, state, fmt.Sprintf("Upgrading from version %d to %d, upgrade path: %v", p.Status.Version, p.Status.UpgradeVersion, p.Status.UpgradePath))
+	case p.Status.UpgradePreflightError != "":
+		set(v1alpha1.ConditionUpgrading, false, "UpgradePreflightFailed", p.Status.UpgradePreflightError)
+	case p.Status.UpgradeAbortedVersion != 0:
+		set(v1alpha1.ConditionUpgrading, false, "UpgradeAborted", fmt.Sprintf("Upgrade to version %d aborted", p.Status.UpgradeAbortedVersion))
+	default:
+		set(v1alpha1.ConditionUpgrading, false, "NoUpgrade", "")
+	}
+
+	if unhealthy := unhealthyMembers(p); len(unhealthy) > 0 {
+		set(v1alpha1.ConditionDegraded, true, "MembersUnhealthy", fmt.Sprintf("Members not healthy: %s", strings.Join(unhealthy, ", ")))
+	} else {
+		set(v1alpha1.ConditionDegraded, false, "MembersHealthy", "")
+	}
+}
+
+// unhealthyMembers returns members missing, or not running, when cluster is expected to be running
+func unhealthyMembers(p *v1alpha1.PatroniPostgres) (unhealthy []string) {
+	if p.Status.State != v1alpha1.PatroniPostgresStateReady {
+		return
+	}
+
+	for idx := range p.Spec.Nodes {
+		name := fmt.Sprintf("%s-%d", p.Name, idx)
+
+		healthy := false
+		for _, member := range p.Status.Members {
+			if member.Name == name {
+				healthy = member.State == patroni.StateRunning || member.State == patroni.StateStreaming
+				break
+			}
+		}
+
+		if !healthy {
+			unhealthy = append(unhealthy, name)
+		}
+	}
+
+	return
+}
+
+// stateReason converts state to a condition reason, e.g. upgrade-primary to UpgradePrimary
+func stateReason(state v1alpha1.PatroniPostgresState) string {
+	if state == "" {
+		return "Initializing"
+	}
+
+	var b strings.Builder
+	for _, word := range strings.Split(string(state), "-") {
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return b.String()
+}
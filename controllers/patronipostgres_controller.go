@@ -29,31 +29,46 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/bootstrap"
 	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/basebackup"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/certificate"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/dbobjects"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/members"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/monitoring"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/networkpolicy"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/patroniconfig"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/pdb"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/pvc"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/rbac"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/service"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/standby"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/switchover"
 	"github.com/k-web-s/patroni-postgres-operator/private/image"
+	"github.com/k-web-s/patroni-postgres-operator/private/metrics"
+	"github.com/k-web-s/patroni-postgres-operator/private/postgres"
 	"github.com/k-web-s/patroni-postgres-operator/private/upgrade"
 )
 
@@ -62,13 +77,20 @@ type PatroniPostgresReconciler struct {
 	client.Client
 	Scheme    *runtime.Scheme
 	Clientset *kubernetes.Clientset
+	Recorder  record.EventRecorder
 }
 
+const (
+	// pollInterval defines how often cluster state is polled from Patroni
+	pollInterval = 30 * time.Second
+)
+
 type reconcilerFunc func(pcontext.Context, *v1alpha1.PatroniPostgres) error
 
 //+kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgres,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgres/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgres/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -81,6 +103,8 @@ func (r *PatroniPostgresReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
+			metrics.Delete(req.Namespace, req.Name)
+
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -93,9 +117,36 @@ func (r *PatroniPostgresReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return
 	}
 
+	original := instance.DeepCopy()
+
+	defer func() {
+		patched, perr := r.patchStatus(ctx, original, instance, err)
+		if perr != nil {
+			if err == nil {
+				err = perr
+			} else {
+				logger.Error(perr, "patching status")
+			}
+		} else {
+			// stuck or degraded clusters are reported as well, as persisted
+			metrics.Update(patched)
+		}
+
+		logger.Info("reconciling done")
+	}()
+
+	logger.Info("reconciling")
+
+	catalog, err := image.LoadCatalog(ctx, r.Client)
+	if err != nil {
+		return
+	}
+
 	// initialization
 	if instance.Status.State == "" {
-		if image.GetImage(instance.Spec.Version) == nil {
+		if catalog.GetImage(instance.Spec.Version) == nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "UnsupportedVersion", "Version %d is not in image catalog, supported versions: %v", instance.Spec.Version, catalog.Versions())
+
 			return ctrl.Result{}, fmt.Errorf("unsupported version %d", instance.Spec.Version)
 		}
 
@@ -103,25 +154,24 @@ func (r *PatroniPostgresReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		instance.Status.State = v1alpha1.PatroniPostgresStateScaling
 		instance.Status.VolumeStatuses = []v1alpha1.VolumeStatus{}
 
-		if err = r.Client.Status().Update(ctx, instance); err != nil {
-			return
+		if bootstrap.Needed(instance) {
+			instance.Status.State = v1alpha1.PatroniPostgresStateBootstrapping
 		}
+
+		instance.Status.Standby = instance.Spec.Standby != nil
+
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Creating cluster with version %d and %d members", instance.Spec.Version, len(instance.Spec.Nodes))
 	}
 
-	wctx, err := pcontext.New(ctx, r.Client, r.Clientset, instance)
+	wctx, err := pcontext.New(ctx, r.Client, r.Clientset, r.Recorder, catalog, instance)
 	if err != nil {
 		return
 	}
 
-	defer func() {
-		if err == nil {
-			err = wctx.Status().Update(wctx, instance)
-		}
-
-		logger.Info("reconciling done")
-	}()
-
-	logger.Info("reconciling")
+	// prepare data of a new cluster
+	if instance.Status.State == v1alpha1.PatroniPostgresStateBootstrapping {
+		return bootstrap.Handle(wctx, instance)
+	}
 
 	// handle upgrade
 	if instance.Status.UpgradeVersion != 0 {
@@ -129,13 +179,49 @@ func (r *PatroniPostgresReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	if instance.Status.State == v1alpha1.PatroniPostgresStateReady {
-		if instance.Status.Version != instance.Spec.Version {
+		if instance.Status.Version == instance.Spec.Version {
+			// allow retrying an aborted upgrade
+			instance.Status.UpgradeAbortedVersion = 0
+		} else if instance.Status.UpgradeAbortedVersion == instance.Spec.Version {
+			logger.Info("upgrade aborted, revert spec.version to retry", "version", instance.Spec.Version)
+		} else {
+			if instance.Status.Standby {
+				return ctrl.Result{}, fmt.Errorf("standby cluster cannot be upgraded, promote it first")
+			}
+
 			if slices.Contains(instance.Status.UpgradeVersions, instance.Spec.Version) {
 				if _, err = configmap.GetSyncLeader(wctx, instance); err != nil {
 					return
 				}
 
-				instance.Status.UpgradeVersion = instance.Spec.Version
+				// the leader's volume is mounted by a running base backup
+				var backupRunning bool
+				if backupRunning, err = basebackup.Running(wctx, instance); err != nil {
+					return
+				}
+				if backupRunning {
+					logger.Info("waiting for base backup to finish before upgrading")
+
+					ret.RequeueAfter = pollInterval
+					return
+				}
+
+				path := catalog.UpgradePath(instance.Status.Version, instance.Spec.Version)
+				if path == nil {
+					return ctrl.Result{}, fmt.Errorf("no upgrade path from version %d to %d", instance.Status.Version, instance.Spec.Version)
+				}
+
+				for _, version := range path {
+					for name := range instance.Spec.Postgresql.Parameters {
+						if err = postgres.ValidateParameterForVersion(name, version); err != nil {
+							return
+						}
+					}
+				}
+
+				instance.Status.UpgradePreflightError = ""
+				instance.Status.UpgradePath = path
+				instance.Status.UpgradeVersion = path[0]
 
 				ret.Requeue = true
 				return
@@ -148,18 +234,29 @@ func (r *PatroniPostgresReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	for _, f := range []reconcilerFunc{
 		pvc.Reconcile,
 		secret.Reconcile,
+		certificate.Reconcile,
 		configmap.Reconcile,
 		rbac.Reconcile,
+		members.Reconcile,
+		switchover.Reconcile,
 		service.Reconcile,
 		statefulset.Reconcile,
+		monitoring.Reconcile,
+		basebackup.Reconcile,
 		networkpolicy.Reconcile,
 		pdb.Reconcile,
+		standby.Reconcile,
+		patroniconfig.Reconcile,
+		dbobjects.Reconcile,
 	} {
 		if err = f(wctx, instance); err != nil {
 			return
 		}
 	}
 
+	// members' state is polled from Patroni
+	ret.RequeueAfter = pollInterval
+
 	return
 }
 
@@ -172,12 +269,53 @@ func (r *PatroniPostgresReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// watch only for status upgrades (i.e. no generation changes)
 	watchPredicates := predicate.Not(predicate.GenerationChangedPredicate{})
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.PatroniPostgres{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.PatroniPostgres{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestForOwner(r.Scheme, r.RESTMapper(), &v1alpha1.PatroniPostgres{})).
 		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestForOwner(r.Scheme, r.RESTMapper(), &v1alpha1.PatroniPostgres{}),
 			builder.WithPredicates(watchPredicates)).
 		Watches(&batchv1.Job{}, handler.EnqueueRequestForOwner(r.Scheme, r.RESTMapper(), &v1alpha1.PatroniPostgres{}),
 			builder.WithPredicates(watchPredicates)).
-		Complete(r)
+		Watches(&v1alpha1.PatroniPostgres{}, handler.EnqueueRequestsFromMapFunc(enqueueCloneSource))
+
+	// reconcile all instances on image catalog change
+	if image.CatalogEnabled() {
+		b = b.Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.enqueueAll),
+			builder.WithPredicates(predicate.NewPredicateFuncs(image.IsCatalog)))
+	}
+
+	return b.Complete(r)
+}
+
+// enqueueCloneSource returns the source cluster of a clone, thus its NetworkPolicy follows the clone's state
+func enqueueCloneSource(_ context.Context, obj client.Object) (requests []reconcile.Request) {
+	p, ok := obj.(*v1alpha1.PatroniPostgres)
+	if !ok || p.Spec.Bootstrap == nil || p.Spec.Bootstrap.Clone == nil {
+		return
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: p.Spec.Bootstrap.Clone.GetNamespace(p.Namespace),
+				Name:      p.Spec.Bootstrap.Clone.Cluster,
+			},
+		},
+	}
+}
+
+// enqueueAll returns requests for all PatroniPostgres instances
+func (r *PatroniPostgresReconciler) enqueueAll(ctx context.Context, _ client.Object) (requests []reconcile.Request) {
+	var list v1alpha1.PatroniPostgresList
+	if err := r.List(ctx, &list); err != nil {
+		log.FromContext(ctx).Error(err, "listing instances")
+
+		return
+	}
+
+	for idx := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[idx])})
+	}
+
+	return
 }
@@ -0,0 +1,237 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/backup"
+	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/basebackup"
+	"github.com/k-web-s/patroni-postgres-operator/private/image"
+	"github.com/k-web-s/patroni-postgres-operator/private/jobresult"
+)
+
+// PatroniPostgresBackupReconciler reconciles a PatroniPostgresBackup object
+type PatroniPostgresBackupReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Clientset *kubernetes.Clientset
+	Recorder  record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgresbackups,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgresbackups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create
+
+// Reconcile runs a backup Job once the cluster is ready, and records its outcome
+func (r *PatroniPostgresBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ret ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	b := &v1alpha1.PatroniPostgresBackup{}
+	if err = r.Get(ctx, req.NamespacedName, b); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if b.Status.Phase == v1alpha1.BackupPhaseCompleted || b.Status.Phase == v1alpha1.BackupPhaseFailed {
+		return
+	}
+
+	original := b.DeepCopy()
+
+	defer func() {
+		if perr := r.Status().Patch(ctx, b, client.MergeFrom(original)); perr != nil {
+			if err == nil {
+				err = perr
+			} else {
+				logger.Error(perr, "patching status")
+			}
+		}
+	}()
+
+	if b.Status.Phase == "" {
+		b.Status.Phase = v1alpha1.BackupPhasePending
+	}
+
+	cluster := &v1alpha1.PatroniPostgres{}
+	if err = r.Get(ctx, types.NamespacedName{Namespace: b.Namespace, Name: b.Spec.Cluster}, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			r.fail(b, fmt.Sprintf("cluster %s not found", b.Spec.Cluster))
+
+			err = nil
+		}
+
+		return
+	}
+
+	if cluster.Spec.Backup == nil {
+		r.fail(b, fmt.Sprintf("spec.backup of cluster %s is not set", cluster.Name))
+
+		return
+	}
+
+	catalog, err := image.LoadCatalog(ctx, r.Client)
+	if err != nil {
+		return
+	}
+
+	wctx, err := pcontext.New(ctx, r.Client, r.Clientset, r.Recorder, catalog, cluster)
+	if err != nil {
+		return
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: b.Namespace, Name: backupJobName(b)}, job)
+	if errors.IsNotFound(err) {
+		err = nil
+
+		if b.Status.Phase == v1alpha1.BackupPhaseRunning {
+			r.fail(b, fmt.Sprintf("job %s disappeared", backupJobName(b)))
+
+			return
+		}
+
+		return r.startBackup(wctx, b, cluster)
+	}
+	if err != nil {
+		return
+	}
+
+	switch {
+	case job.Status.Succeeded > 0:
+		var result basebackup.JobResult
+		if err = jobresult.Get(wctx, job, &result); err != nil {
+			return
+		}
+
+		b.Status.Phase = v1alpha1.BackupPhaseCompleted
+		b.Status.BackupName = result.Name
+		b.Status.StartLSN = result.StartLSN
+		b.Status.StopLSN = result.StopLSN
+		b.Status.Size = result.Size
+		r.finish(b, job)
+
+		r.Recorder.Eventf(b, corev1.EventTypeNormal, "Completed", "Backup of %s completed in %s", b.Status.Member, b.Status.Duration.Duration)
+
+		err = basebackup.Expire(wctx, cluster, &result, b.Name)
+	case job.Status.Failed > 0:
+		r.fail(b, fmt.Sprintf("job %s failed, see its logs", job.Name))
+		r.finish(b, job)
+	}
+
+	return
+}
+
+// startBackup creates the backup Job, once no other backup of the cluster is running
+func (r *PatroniPostgresBackupReconciler) startBackup(ctx pcontext.Context, b *v1alpha1.PatroniPostgresBackup, cluster *v1alpha1.PatroniPostgres) (ret ctrl.Result, err error) {
+	ret.RequeueAfter = pollInterval
+
+	if !backup.Active(cluster) || cluster.Status.State != v1alpha1.PatroniPostgresStateReady {
+		return
+	}
+
+	running, err := basebackup.Running(ctx, cluster)
+	if err != nil || running {
+		return
+	}
+
+	member, err := basebackup.Member(ctx, cluster, b.Spec.GetTarget())
+	if err != nil {
+		return
+	}
+
+	job, err := basebackup.NewJob(ctx, cluster, member, backupJobName(b))
+	if err != nil {
+		return
+	}
+
+	if err = controllerutil.SetControllerReference(b, job, r.Scheme); err != nil {
+		return
+	}
+
+	if err = r.Create(ctx, job); err != nil {
+		return
+	}
+
+	b.Status.Phase = v1alpha1.BackupPhaseRunning
+	b.Status.Member = member.Name
+	b.Status.Repository = backup.ClusterRepository(cluster).Path
+	b.Status.StartTime = &metav1.Time{Time: time.Now()}
+
+	r.Recorder.Eventf(b, corev1.EventTypeNormal, "Started", "Backup of %s started", member.Name)
+
+	return ctrl.Result{}, nil
+}
+
+// fail marks the backup failed
+func (r *PatroniPostgresBackupReconciler) fail(b *v1alpha1.PatroniPostgresBackup, reason string) {
+	b.Status.Phase = v1alpha1.BackupPhaseFailed
+	b.Status.Error = reason
+
+	r.Recorder.Event(b, corev1.EventTypeWarning, "Failed", reason)
+}
+
+// finish records completion time and duration of a finished Job
+func (r *PatroniPostgresBackupReconciler) finish(b *v1alpha1.PatroniPostgresBackup, job *batchv1.Job) {
+	completed := metav1.Now()
+	if job.Status.CompletionTime != nil {
+		completed = *job.Status.CompletionTime
+	}
+
+	b.Status.CompletionTime = &completed
+
+	if b.Status.StartTime != nil {
+		b.Status.Duration = &metav1.Duration{Duration: completed.Sub(b.Status.StartTime.Time).Round(time.Second)}
+	}
+}
+
+func backupJobName(b *v1alpha1.PatroniPostgresBackup) string {
+	return fmt.Sprintf("%s-basebackup", b.Name)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PatroniPostgresBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.PatroniPostgresBackup{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/cron"
+)
+
+const (
+	// scheduledBackupLabel holds the name of the PatroniPostgresScheduledBackup on backups created
+	scheduledBackupLabel = "patronipostgres.kwebs.cloud/scheduled-backup"
+)
+
+// PatroniPostgresScheduledBackupReconciler reconciles a PatroniPostgresScheduledBackup object
+type PatroniPostgresScheduledBackupReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgresscheduledbackups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgresscheduledbackups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgresbackups,verbs=create
+
+// Reconcile creates a PatroniPostgresBackup whenever the schedule is due
+func (r *PatroniPostgresScheduledBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ret ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	sb := &v1alpha1.PatroniPostgresScheduledBackup{}
+	if err = r.Get(ctx, req.NamespacedName, sb); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	original := sb.DeepCopy()
+
+	defer func() {
+		if perr := r.Status().Patch(ctx, sb, client.MergeFrom(original)); perr != nil {
+			if err == nil {
+				err = perr
+			} else {
+				logger.Error(perr, "patching status")
+			}
+		}
+	}()
+
+	if sb.Spec.Suspend {
+		sb.Status.NextScheduleTime = nil
+
+		return
+	}
+
+	schedule, err := cron.Parse(sb.Spec.Schedule)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	next := schedule.Next(now)
+	if next.IsZero() {
+		sb.Status.NextScheduleTime = nil
+
+		return ctrl.Result{}, fmt.Errorf("schedule %q has no next time", sb.Spec.Schedule)
+	}
+
+	// a zero time may have been stored by earlier versions
+	if due := sb.Status.NextScheduleTime; due != nil && !due.IsZero() && !now.Before(due.Time) {
+		b := &v1alpha1.PatroniPostgresBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: sb.Namespace,
+				Name:      fmt.Sprintf("%s-%s", sb.Name, due.UTC().Format("20060102150405")),
+				Labels: map[string]string{
+					scheduledBackupLabel: sb.Name,
+				},
+			},
+			Spec: v1alpha1.PatroniPostgresBackupSpec{
+				Cluster: sb.Spec.Cluster,
+				Target:  sb.Spec.Target,
+			},
+		}
+
+		if err = controllerutil.SetControllerReference(sb, b, r.Scheme); err != nil {
+			return
+		}
+
+		if err = r.Create(ctx, b); err != nil && !errors.IsAlreadyExists(err) {
+			return
+		}
+		err = nil
+
+		sb.Status.LastScheduleTime = due.DeepCopy()
+		sb.Status.LastBackup = b.Name
+	}
+
+	sb.Status.NextScheduleTime = &metav1.Time{Time: next}
+	ret.RequeueAfter = next.Sub(now)
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PatroniPostgresScheduledBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.PatroniPostgresScheduledBackup{}).
+		Complete(r)
+}
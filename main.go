@@ -38,6 +38,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -46,6 +47,7 @@ import (
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
 	"github.com/k-web-s/patroni-postgres-operator/controllers"
+	"github.com/k-web-s/patroni-postgres-operator/private/image"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -76,6 +78,9 @@ func main() {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Cache: cache.Options{
+			ByObject: image.CacheByObject(),
+		},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "patronipostgres.kwebs.cloud",
@@ -96,10 +101,27 @@ func main() {
 		Client:    cl,
 		Scheme:    mgr.GetScheme(),
 		Clientset: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
+		Recorder:  mgr.GetEventRecorderFor("patronipostgres-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PatroniPostgres")
 		os.Exit(1)
 	}
+	if err = (&controllers.PatroniPostgresBackupReconciler{
+		Client:    cl,
+		Scheme:    mgr.GetScheme(),
+		Clientset: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
+		Recorder:  mgr.GetEventRecorderFor("patronipostgresbackup-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PatroniPostgresBackup")
+		os.Exit(1)
+	}
+	if err = (&controllers.PatroniPostgresScheduledBackupReconciler{
+		Client: cl,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PatroniPostgresScheduledBackup")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
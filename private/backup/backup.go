@@ -0,0 +1,287 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+)
+
+const (
+	// AccessKeyIDKey and SecretAccessKeyKey are keys of the credentials Secret
+	AccessKeyIDKey     = "AWS_ACCESS_KEY_ID"
+	SecretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+
+	archiveModeParameter    = "archive_mode"
+	archiveCommandParameter = "archive_command"
+
+	// RestoreCommandKey is set in Patroni's recovery_conf section
+	RestoreCommandKey = "restore_command"
+
+	// pgBackRestStanza is the stanza of all clusters, as each is stored in its own path
+	pgBackRestStanza = "db"
+)
+
+// Repository is a repository of base backups and archived WAL in S3-compatible storage
+type Repository struct {
+	Tool v1alpha1.BackupTool
+	S3   *v1alpha1.S3Storage
+
+	// Path within the bucket
+	Path string
+
+	// Retention is the number of full backups to keep, zero if not enforced
+	Retention int32
+}
+
+// ClusterRepository returns the repository configured in spec.backup
+func ClusterRepository(p *v1alpha1.PatroniPostgres) *Repository {
+	return &Repository{
+		Tool:      p.Spec.Backup.GetTool(),
+		S3:        &p.Spec.Backup.S3,
+		Path:      repositoryPath(p, &p.Spec.Backup.S3, p.Status.Version),
+		Retention: p.Spec.Backup.GetRetention(),
+	}
+}
+
+// RecoveryRepository returns the repository configured in spec.bootstrap.recovery
+func RecoveryRepository(p *v1alpha1.PatroniPostgres) *Repository {
+	recovery := p.Spec.Bootstrap.Recovery
+
+	return &Repository{
+		Tool: recovery.GetTool(),
+		S3:   &recovery.S3,
+		Path: repositoryPath(p, &recovery.S3, p.Spec.Version),
+	}
+}
+
+// StandbyRepository returns the repository configured in spec.standby.archive
+func StandbyRepository(p *v1alpha1.PatroniPostgres) *Repository {
+	archive := p.Spec.Standby.Archive
+
+	return &Repository{
+		Tool: archive.GetTool(),
+		S3:   &archive.S3,
+		Path: repositoryPath(p, &archive.S3, p.Spec.Version),
+	}
+}
+
+// repositoryPath returns path within the bucket. Each major version is archived separately, as WAL of an
+// upgraded cluster does not continue the previous version's.
+func repositoryPath(p *v1alpha1.PatroniPostgres, s3 *v1alpha1.S3Storage, version int) string {
+	base := s3.Path
+	if base == "" {
+		base = path.Join(p.Namespace, p.Name)
+	}
+
+	return path.Join(base, fmt.Sprintf("%d", version))
+}
+
+// Active returns whether archiving is configured in Patroni
+func Active(p *v1alpha1.PatroniPostgres) bool {
+	return slices.Contains(p.Status.ManagedParameters, archiveCommandParameter)
+}
+
+// ParameterNames lists PostgreSQL parameters managed for archiving
+func ParameterNames() []string {
+	return []string{archiveModeParameter, archiveCommandParameter}
+}
+
+// Parameters returns PostgreSQL parameters enabling WAL archiving
+func (r *Repository) Parameters() map[string]string {
+	command := "wal-g wal-push %p"
+	if r.Tool == v1alpha1.BackupToolPgBackRest {
+		command = "pgbackrest archive-push %p"
+	}
+
+	return map[string]string{
+		archiveModeParameter:    "on",
+		archiveCommandParameter: command,
+	}
+}
+
+// RestoreCommand returns command fetching archived WAL
+func (r *Repository) RestoreCommand() string {
+	if r.Tool == v1alpha1.BackupToolPgBackRest {
+		return "pgbackrest archive-get %f %p"
+	}
+
+	return "wal-g wal-fetch %f %p"
+}
+
+// BackupCommand returns shell command taking a full base backup of dataDirectory, then
+// removing backups exceeding retention
+func (r *Repository) BackupCommand(dataDirectory string) string {
+	if r.Tool == v1alpha1.BackupToolPgBackRest {
+		// retention is enforced by backup, see PGBACKREST_REPO1_RETENTION_FULL
+		return "pgbackrest stanza-create && pgbackrest backup --type=full"
+	}
+
+	return fmt.Sprintf("wal-g backup-push %s && wal-g delete retain FULL %d --confirm", dataDirectory, r.Retention)
+}
+
+// InfoCommand returns shell command printing base backups held in the repository as JSON, oldest first
+func (r *Repository) InfoCommand() string {
+	if r.Tool == v1alpha1.BackupToolPgBackRest {
+		return "pgbackrest info --output=json"
+	}
+
+	return "wal-g backup-list --detail --json"
+}
+
+// FetchCommand returns shell command restoring the named base backup, or the latest one, into dataDirectory
+func (r *Repository) FetchCommand(dataDirectory, name string) string {
+	if r.Tool == v1alpha1.BackupToolPgBackRest {
+		if name != "" {
+			return fmt.Sprintf("pgbackrest restore --set=%s", name)
+		}
+
+		return "pgbackrest restore"
+	}
+
+	if name == "" {
+		name = "LATEST"
+	}
+
+	return fmt.Sprintf("wal-g backup-fetch %s %s", dataDirectory, name)
+}
+
+// Env returns environment configuring the backup tool for the cluster whose data resides in dataDirectory
+func (r *Repository) Env(dataDirectory string) (env []corev1.EnvVar, err error) {
+	if r.Tool == v1alpha1.BackupToolPgBackRest {
+		return r.pgBackRestEnv(dataDirectory)
+	}
+
+	return []corev1.EnvVar{
+		{
+			Name:  "WALG_S3_PREFIX",
+			Value: fmt.Sprintf("s3://%s/%s", r.S3.Bucket, r.Path),
+		},
+		{
+			Name:  "AWS_ENDPOINT",
+			Value: r.S3.Endpoint,
+		},
+		{
+			Name:  "AWS_REGION",
+			Value: r.S3.GetRegion(),
+		},
+		{
+			Name:  "AWS_S3_FORCE_PATH_STYLE",
+			Value: "true",
+		},
+		r.credential(AccessKeyIDKey, AccessKeyIDKey),
+		r.credential(SecretAccessKeyKey, SecretAccessKeyKey),
+	}, nil
+}
+
+func (r *Repository) pgBackRestEnv(dataDirectory string) (env []corev1.EnvVar, err error) {
+	endpoint, err := url.Parse(r.S3.Endpoint)
+	if err != nil {
+		return
+	}
+
+	if endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("pgbackrest requires an https endpoint, got %s", r.S3.Endpoint)
+	}
+
+	env = []corev1.EnvVar{
+		{
+			Name:  "PGBACKREST_STANZA",
+			Value: pgBackRestStanza,
+		},
+		{
+			Name:  "PGBACKREST_PG1_PATH",
+			Value: dataDirectory,
+		},
+		{
+			Name:  "PGBACKREST_REPO1_TYPE",
+			Value: "s3",
+		},
+		{
+			Name:  "PGBACKREST_REPO1_S3_BUCKET",
+			Value: r.S3.Bucket,
+		},
+		{
+			Name:  "PGBACKREST_REPO1_S3_ENDPOINT",
+			Value: endpoint.Hostname(),
+		},
+		{
+			Name:  "PGBACKREST_REPO1_S3_REGION",
+			Value: r.S3.GetRegion(),
+		},
+		{
+			Name:  "PGBACKREST_REPO1_S3_URI_STYLE",
+			Value: "path",
+		},
+		{
+			Name:  "PGBACKREST_REPO1_PATH",
+			Value: "/" + r.Path,
+		},
+		{
+			// log to stderr only, log directory may not exist
+			Name:  "PGBACKREST_LOG_LEVEL_FILE",
+			Value: "off",
+		},
+		r.credential("PGBACKREST_REPO1_S3_KEY", AccessKeyIDKey),
+		r.credential("PGBACKREST_REPO1_S3_KEY_SECRET", SecretAccessKeyKey),
+	}
+
+	if r.Retention > 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "PGBACKREST_REPO1_RETENTION_FULL",
+			Value: fmt.Sprintf("%d", r.Retention),
+		})
+	}
+
+	if port := endpoint.Port(); port != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "PGBACKREST_REPO1_STORAGE_PORT",
+			Value: port,
+		})
+	}
+
+	return
+}
+
+func (r *Repository) credential(name, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: r.S3.CredentialsSecret,
+				},
+				Key: key,
+			},
+		},
+	}
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/pvc"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/standby"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/jobresult"
+	"github.com/k-web-s/patroni-postgres-operator/private/security"
+)
+
+var (
+	errBootstrapJobFailed = fmt.Errorf("bootstrap job failed")
+)
+
+func init() {
+	// escape '$' in embedded scripts
+	recoveryScript = strings.ReplaceAll(recoveryScript, "$", "$$")
+	cloneScript = strings.ReplaceAll(cloneScript, "$", "$$")
+	standbyScript = strings.ReplaceAll(standbyScript, "$", "$$")
+}
+
+// method prepares member 0's data directory in a Job
+type method interface {
+	// name of the method, the Job is named after
+	name() string
+
+	// customizePod adds the method's command, environment and volumes to the Job's POD
+	customizePod(pcontext.Context, *v1alpha1.PatroniPostgres, *corev1.PodSpec) error
+
+	// cleanup removes objects created for the Job, once bootstrap succeeded
+	cleanup(pcontext.Context, *v1alpha1.PatroniPostgres) error
+}
+
+type bootstrapJobResult struct {
+	DatabaseSystemIdentifier string `json:"databaseSystemIdentifier"`
+}
+
+// Needed returns whether the cluster is created from existing data
+func Needed(p *v1alpha1.PatroniPostgres) bool {
+	return getMethod(p) != nil
+}
+
+func getMethod(p *v1alpha1.PatroniPostgres) method {
+	if p.Spec.Standby != nil {
+		return standbyCluster{}
+	}
+
+	if p.Spec.Bootstrap == nil {
+		return nil
+	}
+
+	if p.Spec.Bootstrap.Recovery != nil {
+		return recovery{}
+	}
+
+	if p.Spec.Bootstrap.Clone != nil {
+		return clone{}
+	}
+
+	return nil
+}
+
+// Handle prepares member 0's volume before the StatefulSet is created. Once done, the database
+// system identifier is stored in Patroni's configuration, thus member 0 is started with the
+// prepared data, and others clone it.
+func Handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (ret ctrl.Result, err error) {
+	for _, f := range []func(pcontext.Context, *v1alpha1.PatroniPostgres) error{
+		pvc.Reconcile,
+		secret.Reconcile,
+		configmap.Reconcile,
+	} {
+		if err = f(ctx, p); err != nil {
+			return
+		}
+	}
+
+	m := getMethod(p)
+	if m == nil {
+		return ctrl.Result{}, fmt.Errorf("bootstrap method removed before cluster creation")
+	}
+
+	job, err := ensureJob(ctx, p, m)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case job.Status.Succeeded > 0:
+		var result bootstrapJobResult
+		if err = jobresult.Get(ctx, job, &result); err != nil {
+			return
+		}
+
+		// members of a standby cluster must not be promoted
+		if p.Spec.Standby != nil {
+			var config map[string]any
+			if config, err = standby.InitialConfig(p); err != nil {
+				return
+			}
+
+			if err = configmap.SetInitialConfig(ctx, p, config); err != nil {
+				return
+			}
+		}
+
+		if err = configmap.SetInitialDBId(ctx, p, result.DatabaseSystemIdentifier); err != nil {
+			return
+		}
+
+		if err = deleteJob(ctx, job); err != nil {
+			return
+		}
+
+		if err = m.cleanup(ctx, p); err != nil {
+			return
+		}
+
+		ctx.Eventf(corev1.EventTypeNormal, "Bootstrapped", "Database system %s prepared by %s", result.DatabaseSystemIdentifier, m.name())
+
+		p.Status.State = v1alpha1.PatroniPostgresStateScaling
+		ret.Requeue = true
+	case job.Status.Failed > 0:
+		ctx.Eventf(corev1.EventTypeWarning, "BootstrapFailed", "Bootstrap job %s failed, retrying", job.Name)
+
+		if err = deleteJob(ctx, job); err != nil {
+			return
+		}
+
+		err = errBootstrapJobFailed
+	}
+
+	return
+}
+
+// rolesEnv returns names and passwords of roles Patroni connects as, to be set on data
+// prepared by a method, as it still holds passwords of its source
+func rolesEnv(p *v1alpha1.PatroniPostgres) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  "SUPERUSER_USERNAME",
+			Value: statefulset.PatroniSuperuserUsername,
+		},
+		{
+			Name: "SUPERUSER_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secret.Name(p),
+					},
+					Key: secret.SuperUserPasswordKey,
+				},
+			},
+		},
+		{
+			Name:  "REPLICATION_USERNAME",
+			Value: statefulset.PatroniReplicationUsername,
+		},
+		{
+			Name: "REPLICATION_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secret.Name(p),
+					},
+					Key: secret.ReplicationUserPasswordKey,
+				},
+			},
+		},
+	}
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;delete
+
+// ensureJob returns the bootstrap Job, creating it if missing
+func ensureJob(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, m method) (job *batchv1.Job, err error) {
+	jobname := fmt.Sprintf("%s-bootstrap-%s", p.Name, m.name())
+	job = &batchv1.Job{}
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: jobname}, job)
+	if err == nil || !errors.IsNotFound(err) {
+		return
+	}
+
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: jobname,
+		},
+		Spec: batchv1.JobSpec{
+			// failed jobs are recreated after being reported
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: ctx.CommonLabels(),
+				},
+				Spec: corev1.PodSpec{
+					EnableServiceLinks: ptr.To(false),
+					Containers: []corev1.Container{
+						{
+							Name:  m.name(),
+							Image: ctx.Image().Image(),
+							Env: []corev1.EnvVar{
+								{
+									Name:  "PG_VERSION",
+									Value: fmt.Sprintf("%d", p.Status.Version),
+								},
+								{
+									Name:  "PGDATA",
+									Value: statefulset.DataDirectory,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      pvc.VolumeName,
+									MountPath: statefulset.DataVolumeMountPath,
+								},
+							},
+							// only use requests
+							Resources: corev1.ResourceRequirements{
+								Requests: p.Spec.Resources.Requests,
+							},
+							SecurityContext: security.ContainerSecurityContext,
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: pvc.VolumeName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: p.Status.VolumeStatuses[0].ClaimName,
+								},
+							},
+						},
+					},
+					RestartPolicy:    corev1.RestartPolicyNever,
+					SecurityContext:  security.DatabasePodSecurityContext,
+					ImagePullSecrets: p.Spec.ImagePullSecrets,
+					NodeSelector:     p.Spec.NodeSelector,
+					Tolerations:      p.Spec.Tolerations,
+				},
+			},
+		},
+	}
+
+	if err = m.customizePod(ctx, p, &job.Spec.Template.Spec); err != nil {
+		return
+	}
+
+	if err = ctx.SetMeta(job); err != nil {
+		return
+	}
+
+	if err = ctx.Create(ctx, job); err != nil {
+		return
+	}
+
+	ctx.Eventf(corev1.EventTypeNormal, "BootstrapStarted", "Preparing data of %s by %s", p.Status.VolumeStatuses[0].ClaimName, m.name())
+
+	return
+}
+
+func deleteJob(ctx pcontext.Context, job *batchv1.Job) (err error) {
+	deletePropagationPolicy := metav1.DeletePropagationBackground
+
+	return ctx.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &deletePropagationPolicy})
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package bootstrap
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/certificate"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/service"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+)
+
+var (
+	//go:embed bootstrap-scripts/clone
+	cloneScript string
+)
+
+// clone takes a base backup of a member of a running cluster
+type clone struct{}
+
+func (clone) name() string {
+	return "clone"
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update;delete
+
+func (clone) customizePod(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, podSpec *corev1.PodSpec) (err error) {
+	spec := p.Spec.Bootstrap.Clone
+	source := &v1alpha1.PatroniPostgres{}
+
+	if err = ctx.Get(ctx, types.NamespacedName{Namespace: spec.GetNamespace(p.Namespace), Name: spec.Cluster}, source); err != nil {
+		return
+	}
+
+	if !cloneAllowed(source, p) {
+		return fmt.Errorf("source cluster %s/%s does not allow cloning into namespace %s", source.Namespace, source.Name, p.Namespace)
+	}
+
+	if source.Status.Version != p.Status.Version {
+		return fmt.Errorf("source cluster %s/%s is of version %d", source.Namespace, source.Name, source.Status.Version)
+	}
+
+	member := cloneMember(source)
+	if member == "" {
+		return fmt.Errorf("source cluster %s/%s has no member to clone", source.Namespace, source.Name)
+	}
+
+	// Secrets cannot be referenced across namespaces, thus credentials are copied
+	tls, err := copySourceSecret(ctx, p, source)
+	if err != nil {
+		return
+	}
+
+	container := &podSpec.Containers[0]
+	container.Command = []string{"sh", "-c", cloneScript}
+	container.Env = append(container.Env,
+		corev1.EnvVar{
+			Name:  "PGHOST",
+			Value: fmt.Sprintf("%s.%s.%s.svc", member, service.HeadlessServiceName(source), source.Namespace),
+		},
+		corev1.EnvVar{
+			Name:  "PGPORT",
+			Value: fmt.Sprintf("%d", service.PostgresPort),
+		},
+		corev1.EnvVar{
+			Name:  "PGUSER",
+			Value: statefulset.PatroniReplicationUsername,
+		},
+		corev1.EnvVar{
+			Name: "PGPASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cloneSecretName(p),
+					},
+					Key: secret.ReplicationUserPasswordKey,
+				},
+			},
+		},
+	)
+	container.Env = append(container.Env, rolesEnv(p)...)
+
+	if !tls {
+		return
+	}
+
+	certificate.MountCA(podSpec, cloneSecretName(p), "PG")
+
+	return
+}
+
+func (clone) cleanup(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      cloneSecretName(p),
+		},
+	}
+
+	if err = ctx.Delete(ctx, s); errors.IsNotFound(err) {
+		err = nil
+	}
+
+	return
+}
+
+// cloneMember returns the streaming replica lagging the least, or the leader if there is none
+func cloneMember(source *v1alpha1.PatroniPostgres) string {
+	name := source.Status.Leader
+
+	var lag int64 = -1
+	for _, member := range source.Status.Members {
+		if member.State != "streaming" || member.Lag == nil {
+			continue
+		}
+
+		if lag == -1 || *member.Lag < lag {
+			name, lag = member.Name, *member.Lag
+		}
+	}
+
+	return name
+}
+
+// copySourceSecret copies the source cluster's replication password, and its CA certificate once
+// ssl is turned on there. Returns whether the server's certificate can be verified.
+func copySourceSecret(ctx pcontext.Context, p, source *v1alpha1.PatroniPostgres) (tls bool, err error) {
+	sourceSecret := &corev1.Secret{}
+	if err = ctx.Get(ctx, types.NamespacedName{Namespace: source.Namespace, Name: secret.Name(source)}, sourceSecret); err != nil {
+		return
+	}
+
+	password := sourceSecret.Data[secret.ReplicationUserPasswordKey]
+	if len(password) == 0 {
+		return false, fmt.Errorf("secret %s/%s has no %s", source.Namespace, sourceSecret.Name, secret.ReplicationUserPasswordKey)
+	}
+
+	data := map[string][]byte{
+		secret.ReplicationUserPasswordKey: password,
+	}
+
+	if certificate.Active(source) && source.Status.TLS != nil {
+		tlsSecret := &corev1.Secret{}
+		if err = ctx.Get(ctx, types.NamespacedName{Namespace: source.Namespace, Name: source.Status.TLS.SecretName}, tlsSecret); err != nil {
+			return
+		}
+
+		if ca := tlsSecret.Data[certificate.CAKey]; len(ca) > 0 {
+			data[certificate.CAKey] = ca
+			tls = true
+		}
+	}
+
+	s := &corev1.Secret{}
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: cloneSecretName(p)}, s)
+	if err != nil && !errors.IsNotFound(err) {
+		return
+	}
+
+	create := errors.IsNotFound(err)
+	if create {
+		s.Name = cloneSecretName(p)
+	}
+
+	if err = ctx.SetMeta(s); err != nil {
+		return
+	}
+
+	s.Data = data
+
+	if create {
+		err = ctx.Create(ctx, s)
+	} else {
+		err = ctx.Update(ctx, s)
+	}
+
+	return
+}
+
+// cloneSecretName returns name of Secret holding credentials of the source cluster
+func cloneSecretName(p *v1alpha1.PatroniPostgres) string {
+	return fmt.Sprintf("%s-clone-source", p.Name)
+}
+
+// +kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgres,verbs=list
+
+// Cloning returns clusters being bootstrapped by cloning p
+func Cloning(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (clones []v1alpha1.PatroniPostgres, err error) {
+	var list v1alpha1.PatroniPostgresList
+	if err = ctx.List(ctx, &list); err != nil {
+		return
+	}
+
+	for _, item := range list.Items {
+		if item.Status.State != v1alpha1.PatroniPostgresStateBootstrapping || item.Spec.Bootstrap == nil || item.Spec.Bootstrap.Clone == nil {
+			continue
+		}
+
+		if item.Spec.Bootstrap.Clone.Cluster == p.Name && item.Spec.Bootstrap.Clone.GetNamespace(item.Namespace) == p.Namespace && cloneAllowed(p, &item) {
+			clones = append(clones, item)
+		}
+	}
+
+	return
+}
+
+// cloneAllowed returns whether source lets clone copy it
+func cloneAllowed(source, clone *v1alpha1.PatroniPostgres) bool {
+	return slices.Contains(source.Spec.CloneAllowedNamespaces, clone.Namespace)
+}
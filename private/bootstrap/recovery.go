@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package bootstrap
+
+import (
+	_ "embed"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/backup"
+	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+)
+
+const (
+	// minRecoveryVersion is the first version without recovery.conf
+	minRecoveryVersion = 12
+)
+
+var (
+	//go:embed bootstrap-scripts/recovery
+	recoveryScript string
+)
+
+// recovery restores a base backup, and recovers it from archived WAL
+type recovery struct{}
+
+func (recovery) name() string {
+	return "recovery"
+}
+
+func (recovery) customizePod(_ pcontext.Context, p *v1alpha1.PatroniPostgres, podSpec *corev1.PodSpec) (err error) {
+	// recovery is configured with recovery.signal and server options
+	if p.Spec.Version < minRecoveryVersion {
+		return fmt.Errorf("spec.bootstrap.recovery requires version %d or later", minRecoveryVersion)
+	}
+
+	spec := p.Spec.Bootstrap.Recovery
+	container := &podSpec.Containers[0]
+	repository := backup.RecoveryRepository(p)
+
+	env, err := repository.Env(statefulset.DataDirectory)
+	if err != nil {
+		return
+	}
+
+	container.Command = []string{"sh", "-c", recoveryScript}
+	container.Env = append(container.Env, env...)
+	container.Env = append(container.Env,
+		corev1.EnvVar{
+			Name:  "FETCH_COMMAND",
+			Value: repository.FetchCommand(statefulset.DataDirectory, spec.Backup),
+		},
+		corev1.EnvVar{
+			Name:  "RESTORE_COMMAND",
+			Value: repository.RestoreCommand(),
+		},
+		corev1.EnvVar{
+			Name:  "RECOVERY_OPTIONS",
+			Value: recoveryOptions(spec.Target),
+		},
+	)
+	container.Env = append(container.Env, rolesEnv(p)...)
+
+	return
+}
+
+func (recovery) cleanup(pcontext.Context, *v1alpha1.PatroniPostgres) error {
+	return nil
+}
+
+// recoveryOptions returns server options setting the recovery target
+func recoveryOptions(target *v1alpha1.RecoveryTarget) string {
+	switch {
+	case target == nil:
+		return ""
+	case target.Time != nil:
+		return fmt.Sprintf("-c recovery_target_time='%s'", target.Time.UTC().Format(time.DateTime+"+00"))
+	case target.LSN != "":
+		return fmt.Sprintf("-c recovery_target_lsn='%s'", target.LSN)
+	case target.Name != "":
+		return fmt.Sprintf("-c recovery_target_name='%s'", target.Name)
+	}
+
+	return ""
+}
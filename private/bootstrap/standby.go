@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package bootstrap
+
+import (
+	_ "embed"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/backup"
+	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+)
+
+var (
+	//go:embed bootstrap-scripts/standby
+	standbyScript string
+)
+
+// standbyCluster copies data of the source cluster of a standby cluster, either with a base backup
+// streamed from its primary, or by fetching its latest base backup from the archive
+type standbyCluster struct{}
+
+func (standbyCluster) name() string {
+	return "standby"
+}
+
+func (standbyCluster) customizePod(_ pcontext.Context, p *v1alpha1.PatroniPostgres, podSpec *corev1.PodSpec) (err error) {
+	spec := p.Spec.Standby
+	container := &podSpec.Containers[0]
+
+	container.Command = []string{"sh", "-c", standbyScript}
+
+	// the standby leader connects with the cluster's replication user, thus its password must match the source's
+	if spec.Host != "" {
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name:  "PGHOST",
+				Value: spec.Host,
+			},
+			corev1.EnvVar{
+				Name:  "PGPORT",
+				Value: fmt.Sprintf("%d", spec.GetPort()),
+			},
+			corev1.EnvVar{
+				Name:  "PGUSER",
+				Value: statefulset.PatroniReplicationUsername,
+			},
+			corev1.EnvVar{
+				Name: "PGPASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name(p),
+						},
+						Key: secret.ReplicationUserPasswordKey,
+					},
+				},
+			},
+		)
+
+		return
+	}
+
+	if spec.Archive == nil {
+		return fmt.Errorf("spec.standby requires host or archive")
+	}
+
+	repository := backup.StandbyRepository(p)
+
+	env, err := repository.Env(statefulset.DataDirectory)
+	if err != nil {
+		return
+	}
+
+	container.Env = append(container.Env, env...)
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "FETCH_COMMAND",
+		Value: repository.FetchCommand(statefulset.DataDirectory, ""),
+	})
+
+	return
+}
+
+func (standbyCluster) cleanup(pcontext.Context, *v1alpha1.PatroniPostgres) error {
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -51,6 +52,8 @@ const (
 
 	// Component names
 	ComponentPostgres = "postgres"
+	ComponentMetrics  = "metrics"
+	ComponentBackup   = "backup"
 )
 
 type Context interface {
@@ -74,19 +77,30 @@ type Context interface {
 
 	// Image returns the current image to be used
 	Image() image.Image
+
+	// Catalog returns the image catalog
+	Catalog() *image.Catalog
+
+	// Event records an event on current PatroniPostgres instance
+	Event(eventtype, reason, message string)
+
+	// Eventf records an event on current PatroniPostgres instance, with message formatted
+	Eventf(eventtype, reason, messageFmt string, args ...any)
 }
 
-func New(ctx gocontext.Context, cl client.Client, clientset *kubernetes.Clientset, pp *v1alpha1.PatroniPostgres) (Context, error) {
-	im := image.GetImage(pp.Status.Version)
+func New(ctx gocontext.Context, cl client.Client, clientset *kubernetes.Clientset, recorder record.EventRecorder, catalog *image.Catalog, pp *v1alpha1.PatroniPostgres) (Context, error) {
+	im := catalog.GetImage(pp.Status.Version)
 	if im == nil {
-		return nil, fmt.Errorf("wctx: unsupported version: %d", pp.Spec.Version)
+		return nil, fmt.Errorf("wctx: unsupported version: %d", pp.Status.Version)
 	}
 
 	return &context{
 		Context:   ctx,
 		Client:    cl,
 		clientset: clientset,
+		recorder:  recorder,
 		pp:        pp,
+		catalog:   catalog,
 		im:        im,
 	}, nil
 }
@@ -95,19 +109,24 @@ type context struct {
 	gocontext.Context
 	client.Client
 	clientset *kubernetes.Clientset
+	recorder  record.EventRecorder
 	pp        *v1alpha1.PatroniPostgres
+	catalog   *image.Catalog
 	im        image.Image
 }
 
 func (c *context) CommonLabels() (ret map[string]string) {
-	ret = map[string]string{
+	return ClusterLabels(c.pp)
+}
+
+// ClusterLabels returns common labels of given cluster, to select objects of other clusters
+func ClusterLabels(p *v1alpha1.PatroniPostgres) map[string]string {
+	return map[string]string{
 		nameLabel:        nameValue,
-		instanceLabel:    c.pp.Name,
+		instanceLabel:    p.Name,
 		managedByLabel:   managedByValue,
-		clusterNameLabel: c.pp.Name,
+		clusterNameLabel: p.Name,
 	}
-
-	return
 }
 
 func (c *context) PodLabels(component string) (ret map[string]string) {
@@ -139,3 +158,15 @@ func (c *context) Clientset() *kubernetes.Clientset {
 func (c *context) Image() image.Image {
 	return c.im
 }
+
+func (c *context) Catalog() *image.Catalog {
+	return c.catalog
+}
+
+func (c *context) Event(eventtype, reason, message string) {
+	c.recorder.Event(c.pp, eventtype, reason, message)
+}
+
+func (c *context) Eventf(eventtype, reason, messageFmt string, args ...any) {
+	c.recorder.Eventf(c.pp, eventtype, reason, messageFmt, args...)
+}
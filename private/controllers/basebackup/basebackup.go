@@ -0,0 +1,233 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package basebackup
+
+import (
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/backup"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/cron"
+	"github.com/k-web-s/patroni-postgres-operator/private/jobresult"
+	"github.com/k-web-s/patroni-postgres-operator/private/postgres"
+)
+
+// Reconcile schedules base backups, and collects archiver statistics. Status is set once
+// archiving is configured in Patroni, see patroniconfig.
+func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if p.Spec.Backup == nil {
+		p.Status.Backup = nil
+
+		return deleteJob(ctx, p)
+	}
+
+	for _, name := range backup.ParameterNames() {
+		if _, ok := p.Spec.Postgresql.Parameters[name]; ok {
+			return fmt.Errorf("parameter %s is managed by spec.backup", name)
+		}
+	}
+
+	schedule, err := cron.Parse(p.Spec.Backup.GetSchedule())
+	if err != nil {
+		return
+	}
+
+	if !backup.Active(p) {
+		return
+	}
+
+	if p.Status.Backup == nil {
+		p.Status.Backup = &v1alpha1.BackupStatus{}
+	}
+
+	if err = handleJob(ctx, p); err != nil {
+		return
+	}
+
+	// primary must be reachable
+	if p.Status.State != v1alpha1.PatroniPostgresStateReady {
+		return
+	}
+
+	if err = updateArchiverStatus(ctx, p); err != nil {
+		return
+	}
+
+	// without a scheduled backup, e.g. when archiving was just enabled, one is taken immediately
+	now := time.Now().UTC()
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return fmt.Errorf("schedule %q has no next time", p.Spec.Backup.GetSchedule())
+	}
+
+	if due := p.Status.Backup.NextBackup; due == nil || !now.Before(due.Time) {
+		// one backup runs at a time, a due backup is taken once possible
+		var running bool
+		if running, err = Running(ctx, p); err != nil || running || p.Status.Leader == "" {
+			return
+		}
+
+		if err = createJob(ctx, p); err != nil {
+			return
+		}
+	}
+
+	p.Status.Backup.NextBackup = &metav1.Time{Time: next}
+
+	return
+}
+
+// JobName returns name of base backup Job
+func JobName(p *v1alpha1.PatroniPostgres) string {
+	return fmt.Sprintf("%s-backup", p.Name)
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;delete
+
+// createJob creates a Job taking a scheduled base backup of the leader
+func createJob(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	leader, err := Member(ctx, p, v1alpha1.BackupTargetPrimary)
+	if err != nil {
+		return
+	}
+
+	job, err := NewJob(ctx, p, leader, JobName(p))
+	if err != nil {
+		return
+	}
+
+	if err = ctx.Create(ctx, job); err != nil {
+		return
+	}
+
+	ctx.Eventf(corev1.EventTypeNormal, "BackupStarted", "Base backup of %s started", leader.Name)
+
+	return
+}
+
+// handleJob records the outcome of a finished scheduled Job, then removes it
+func handleJob(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	job := &batchv1.Job{}
+	if err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: JobName(p)}, job); err != nil {
+		if errors.IsNotFound(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	if job.DeletionTimestamp != nil {
+		return
+	}
+
+	switch {
+	case job.Status.Succeeded > 0:
+		completed := metav1.Now()
+		if job.Status.CompletionTime != nil {
+			completed = *job.Status.CompletionTime
+		}
+
+		var result JobResult
+		if err = jobresult.Get(ctx, job, &result); err != nil {
+			return
+		}
+
+		p.Status.Backup.LastBackup = &completed
+		ctx.Eventf(corev1.EventTypeNormal, "BackupCompleted", "Base backup completed in %s", completed.Sub(job.CreationTimestamp.Time).Round(time.Second))
+
+		if err = Expire(ctx, p, &result, ""); err != nil {
+			return
+		}
+	case job.Status.Failed > 0:
+		p.Status.Backup.LastFailedBackup = ptr.To(metav1.Now())
+		ctx.Eventf(corev1.EventTypeWarning, "BackupFailed", "Base backup failed, see logs of job %s", job.Name)
+	default:
+		return
+	}
+
+	return deleteJob(ctx, p)
+}
+
+func deleteJob(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      JobName(p),
+		},
+	}
+
+	deletePropagationPolicy := metav1.DeletePropagationBackground
+	if err = ctx.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &deletePropagationPolicy}); errors.IsNotFound(err) {
+		err = nil
+	}
+
+	return
+}
+
+// updateArchiverStatus reports WAL archiving statistics of the primary
+func updateArchiverStatus(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	conn, err := postgres.Connect(ctx, p, "postgres")
+	if err != nil {
+		return
+	}
+	defer conn.Close(ctx)
+
+	var (
+		lastArchivedWAL, lastFailedWAL   *string
+		lastArchivedTime, lastFailedTime *time.Time
+	)
+
+	status := p.Status.Backup
+	if err = conn.QueryRow(ctx, "SELECT archived_count, last_archived_wal, last_archived_time, failed_count, last_failed_wal, last_failed_time FROM pg_catalog.pg_stat_archiver").
+		Scan(&status.ArchivedCount, &lastArchivedWAL, &lastArchivedTime, &status.FailedCount, &lastFailedWAL, &lastFailedTime); err != nil {
+		return
+	}
+
+	status.LastArchivedWAL = ptr.Deref(lastArchivedWAL, "")
+	status.LastArchivedTime = toTime(lastArchivedTime)
+	status.LastFailedWAL = ptr.Deref(lastFailedWAL, "")
+	status.LastFailedTime = toTime(lastFailedTime)
+
+	return
+}
+
+func toTime(t *time.Time) *metav1.Time {
+	if t == nil {
+		return nil
+	}
+
+	return &metav1.Time{Time: *t}
+}
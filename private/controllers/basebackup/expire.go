@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package basebackup
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+)
+
+// +kubebuilder:rbac:groups=kwebs.cloud,resources=patronipostgresbackups,verbs=list;delete
+
+// Expire deletes finished backups of the cluster no longer held in the repository result was
+// reported from. Backups without a name, and failed ones, are deleted once older than the oldest
+// named backup still available. Backups in repositories of other major versions are left alone.
+// keep is never deleted.
+func Expire(ctx context.Context, p *v1alpha1.PatroniPostgres, result *JobResult, keep string) (err error) {
+	available := result.Backups
+
+	// an empty list means the tool could not be queried, or nothing is held yet, while
+	// backups missing from a truncated one may still be held
+	if len(available) == 0 || result.Truncated || result.Repository == "" {
+		return
+	}
+
+	var list v1alpha1.PatroniPostgresBackupList
+	if err = ctx.List(ctx, &list, client.InNamespace(p.Namespace)); err != nil {
+		return
+	}
+
+	backups := slices.DeleteFunc(list.Items, func(item v1alpha1.PatroniPostgresBackup) bool {
+		return item.Spec.Cluster != p.Name || item.Status.Repository != result.Repository || item.Name == keep ||
+			(item.Status.Phase != v1alpha1.BackupPhaseCompleted && item.Status.Phase != v1alpha1.BackupPhaseFailed)
+	})
+
+	var oldest *v1alpha1.PatroniPostgresBackup
+	for idx := range backups {
+		item := &backups[idx]
+
+		if item.Status.BackupName != "" && slices.Contains(available, item.Status.BackupName) &&
+			(oldest == nil || item.CreationTimestamp.Before(&oldest.CreationTimestamp)) {
+			oldest = item
+		}
+	}
+
+	for idx := range backups {
+		item := &backups[idx]
+
+		if item.Status.Phase == v1alpha1.BackupPhaseCompleted && item.Status.BackupName != "" {
+			if slices.Contains(available, item.Status.BackupName) {
+				continue
+			}
+		} else if oldest == nil || !item.CreationTimestamp.Before(&oldest.CreationTimestamp) {
+			continue
+		}
+
+		if err = ctx.Delete(ctx, item); client.IgnoreNotFound(err) != nil {
+			return
+		}
+		err = nil
+
+		ctx.Eventf(corev1.EventTypeNormal, "BackupExpired", "Backup %s is no longer held in the repository, deleted", item.Name)
+	}
+
+	return
+}
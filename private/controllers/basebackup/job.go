@@ -0,0 +1,266 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package basebackup
+
+import (
+	_ "embed"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/backup"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/certificate"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/pvc"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/service"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/security"
+)
+
+var (
+	//go:embed backup-script
+	backupScript string
+)
+
+// JobResult is the result of a succeeded backup Job, as reported by the backup tool
+type JobResult struct {
+	// Repository is the path of the repository within the bucket
+	Repository string `json:"repository"`
+
+	// Name of the base backup taken
+	Name     string `json:"name"`
+	StartLSN string `json:"startLSN"`
+	StopLSN  string `json:"stopLSN"`
+	Size     int64  `json:"size"`
+
+	// Backups lists base backups held in the repository after retention was applied, newest last
+	Backups []string `json:"backups"`
+
+	// Truncated is set if Backups holds only the newest ones
+	Truncated bool `json:"truncated"`
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=list
+
+// Running returns whether a backup Job of the cluster is running
+func Running(ctx context.Context, p *v1alpha1.PatroniPostgres) (running bool, err error) {
+	var jobs batchv1.JobList
+	if err = ctx.List(ctx, &jobs, &client.ListOptions{Namespace: p.Namespace, LabelSelector: labels.SelectorFromSet(ctx.PodLabels(context.ComponentBackup))}); err != nil {
+		return
+	}
+
+	for _, job := range jobs.Items {
+		if job.Status.Succeeded+job.Status.Failed == 0 {
+			return true, nil
+		}
+	}
+
+	return
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get
+
+// Member returns the pod of the member to back up. Replicas are chosen with wal-g only, as
+// pgBackRest backs up replicas only along with the primary.
+func Member(ctx context.Context, p *v1alpha1.PatroniPostgres, target v1alpha1.BackupTarget) (pod *corev1.Pod, err error) {
+	name := p.Status.Leader
+
+	if target == v1alpha1.BackupTargetPreferReplica && p.Spec.Backup.GetTool() == v1alpha1.BackupToolWALG {
+		var lag int64 = -1
+		for _, member := range p.Status.Members {
+			if member.State != "streaming" || member.Lag == nil {
+				continue
+			}
+
+			if lag == -1 || *member.Lag < lag {
+				name, lag = member.Name, *member.Lag
+			}
+		}
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("no member to back up")
+	}
+
+	pod = &corev1.Pod{}
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: name}, pod)
+
+	return
+}
+
+// NewJob returns a Job taking a base backup of member. The member's volume is mounted
+// read-only, thus the Job runs on the member's node.
+func NewJob(ctx context.Context, p *v1alpha1.PatroniPostgres, member *corev1.Pod, name string) (job *batchv1.Job, err error) {
+	var claimName string
+	for _, volume := range member.Spec.Volumes {
+		if volume.Name == pvc.VolumeName && volume.PersistentVolumeClaim != nil {
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	if claimName == "" {
+		return nil, fmt.Errorf("no data volume found in %s", member.Name)
+	}
+
+	repository := backup.ClusterRepository(p)
+
+	env, err := repository.Env(statefulset.DataDirectory)
+	if err != nil {
+		return
+	}
+
+	// connect to the member whose volume is mounted
+	env = append(env,
+		corev1.EnvVar{
+			Name:  "BACKUP_COMMAND",
+			Value: repository.BackupCommand(statefulset.DataDirectory),
+		},
+		corev1.EnvVar{
+			Name:  "BACKUP_TOOL",
+			Value: string(repository.Tool),
+		},
+		corev1.EnvVar{
+			Name:  "INFO_COMMAND",
+			Value: repository.InfoCommand(),
+		},
+		corev1.EnvVar{
+			Name:  "REPOSITORY_PATH",
+			Value: repository.Path,
+		},
+		corev1.EnvVar{
+			Name:  "PGDATA",
+			Value: statefulset.DataDirectory,
+		},
+		corev1.EnvVar{
+			Name:  "PG_VERSION",
+			Value: fmt.Sprintf("%d", p.Status.Version),
+		},
+		corev1.EnvVar{
+			Name:  "PGHOST",
+			Value: fmt.Sprintf("%s.%s", member.Name, service.HeadlessServiceName(p)),
+		},
+		corev1.EnvVar{
+			Name:  "PGUSER",
+			Value: statefulset.PatroniSuperuserUsername,
+		},
+		corev1.EnvVar{
+			Name: "PGPASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secret.Name(p),
+					},
+					Key: secret.SuperUserPasswordKey,
+				},
+			},
+		},
+		corev1.EnvVar{
+			Name:  "PGDATABASE",
+			Value: "postgres",
+		},
+	)
+
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: batchv1.JobSpec{
+			// failures are reported, and retried on next schedule
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: ctx.PodLabels(context.ComponentBackup),
+				},
+				Spec: corev1.PodSpec{
+					EnableServiceLinks: ptr.To(false),
+					NodeName:           member.Spec.NodeName,
+					Containers: []corev1.Container{
+						{
+							Name:    "backup",
+							Image:   ctx.Image().Image(),
+							Command: []string{"sh", "-c", backupScript},
+							Env:     env,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      pvc.VolumeName,
+									MountPath: statefulset.DataVolumeMountPath,
+									ReadOnly:  true,
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("10m"),
+									corev1.ResourceMemory: resource.MustParse("64Mi"),
+								},
+							},
+							SecurityContext: security.ContainerSecurityContext,
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: pvc.VolumeName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: claimName,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					RestartPolicy:    corev1.RestartPolicyNever,
+					SecurityContext:  security.DatabasePodSecurityContext,
+					ImagePullSecrets: p.Spec.ImagePullSecrets,
+					Tolerations:      p.Spec.Tolerations,
+				},
+			},
+		},
+	}
+
+	verifyServer(p, &job.Spec.Template.Spec)
+
+	if err = ctx.SetMeta(job); err != nil {
+		return
+	}
+
+	job.Labels = ctx.PodLabels(context.ComponentBackup)
+
+	return
+}
+
+// verifyServer makes the backup tool connect with verify-full, once ssl is turned on
+func verifyServer(p *v1alpha1.PatroniPostgres, spec *corev1.PodSpec) {
+	if certificate.Active(p) && p.Status.TLS != nil {
+		certificate.MountCA(spec, p.Status.TLS.SecretName, "PG")
+	}
+}
@@ -0,0 +1,394 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package certificate
+
+import (
+	"crypto/x509"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/service"
+	"github.com/k-web-s/patroni-postgres-operator/private/patroni"
+	"github.com/k-web-s/patroni-postgres-operator/private/pki"
+)
+
+const (
+	// VolumeName is the name of the volume holding the server certificate
+	VolumeName = "tls"
+
+	// MountPath is where the server certificate is mounted in members
+	MountPath = "/etc/postgresql/tls"
+
+	// CAKey holds the CA certificate in Secrets
+	CAKey = "ca.crt"
+
+	// caKeyKey holds the CA private key in the generated CA Secret
+	caKeyKey = "ca.key"
+
+	// sslParameter is managed in Patroni dynamic configuration while TLS is enabled
+	sslParameter = "ssl"
+
+	caValidity = 10 * 365 * 24 * time.Hour
+
+	// reloadDelay allows kubelet to update mounted Secrets before members are reloaded
+	reloadDelay = 2 * time.Minute
+)
+
+var (
+	errClientCertificatesNeedCA = fmt.Errorf("certificate authentication requires operator generated certificates, spec.tls.secretName must not be set")
+)
+
+// Spec returns TLS configuration in effect, which is spec.tls, or defaults if a role uses
+// certificate authentication. Returns nil if TLS is disabled.
+func Spec(p *v1alpha1.PatroniPostgres) *v1alpha1.TLS {
+	if p.Spec.TLS != nil {
+		return p.Spec.TLS
+	}
+
+	if slices.ContainsFunc(p.Spec.Roles, func(role v1alpha1.Role) bool { return role.CertificateAuthentication }) {
+		return &v1alpha1.TLS{}
+	}
+
+	return nil
+}
+
+// SecretName returns name of Secret holding the server certificate
+func SecretName(p *v1alpha1.PatroniPostgres) string {
+	if name := Spec(p).SecretName; name != "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s-tls", p.Name)
+}
+
+// caSecretName returns name of Secret holding the generated CA
+func caSecretName(p *v1alpha1.PatroniPostgres) string {
+	return fmt.Sprintf("%s-ca", p.Name)
+}
+
+// Active reports whether ssl is turned on in Patroni dynamic configuration, thus clients may require TLS
+func Active(p *v1alpha1.PatroniPostgres) bool {
+	return slices.Contains(p.Status.ManagedParameters, sslParameter)
+}
+
+// Mounted reports whether all members run with a certificate mounted, thus ssl may be turned on
+func Mounted(p *v1alpha1.PatroniPostgres) bool {
+	return p.Status.TLS != nil && p.Status.TLS.Mounted
+}
+
+// Parameters returns PostgreSQL parameters enabling TLS with the mounted certificate
+func Parameters() map[string]string {
+	return map[string]string{
+		sslParameter:    "on",
+		"ssl_cert_file": path.Join(MountPath, corev1.TLSCertKey),
+		"ssl_key_file":  path.Join(MountPath, corev1.TLSPrivateKeyKey),
+		"ssl_ca_file":   path.Join(MountPath, CAKey),
+	}
+}
+
+// MountCA mounts the CA certificate held in secretName into the first container of spec, and makes
+// clients connect with verify-full through <envPrefix>SSLMODE and <envPrefix>SSLROOTCERT
+func MountCA(spec *corev1.PodSpec, secretName, envPrefix string) {
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: VolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  CAKey,
+						Path: CAKey,
+					},
+				},
+			},
+		},
+	})
+
+	spec.Containers[0].VolumeMounts = append(spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      VolumeName,
+		MountPath: MountPath,
+		ReadOnly:  true,
+	})
+
+	spec.Containers[0].Env = append(spec.Containers[0].Env,
+		corev1.EnvVar{
+			Name:  envPrefix + "SSLMODE",
+			Value: "verify-full",
+		},
+		corev1.EnvVar{
+			Name:  envPrefix + "SSLROOTCERT",
+			Value: path.Join(MountPath, CAKey),
+		},
+	)
+}
+
+// DNSNames returns names the server certificate must cover: the cluster's Services, and members
+// through the headless Service
+func DNSNames(p *v1alpha1.PatroniPostgres) (names []string) {
+	for _, name := range []string{
+		p.Name,
+		service.HeadlessServiceName(p),
+		service.ReplicaServiceName(p),
+		"*." + service.HeadlessServiceName(p),
+	} {
+		names = append(names,
+			name,
+			fmt.Sprintf("%s.%s", name, p.Namespace),
+			fmt.Sprintf("%s.%s.svc", name, p.Namespace),
+		)
+	}
+
+	return
+}
+
+// RootCAs returns a pool with the CA of the mounted server certificate
+func RootCAs(ctx context.Context, p *v1alpha1.PatroniPostgres) (pool *x509.CertPool, err error) {
+	if p.Status.TLS == nil {
+		return nil, fmt.Errorf("no server certificate")
+	}
+
+	secret := &corev1.Secret{}
+	if err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: p.Status.TLS.SecretName}, secret); err != nil {
+		return
+	}
+
+	pool = x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data[CAKey]) {
+		err = fmt.Errorf("secret %s has no valid %s", secret.Name, CAKey)
+	}
+
+	return
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update
+
+// Reconcile ensures the server certificate, renewing generated ones before expiry, and reloads
+// members once a changed certificate is mounted
+func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	spec := Spec(p)
+	if spec == nil {
+		// keep certificate mounted until ssl is turned off
+		if !Active(p) {
+			p.Status.TLS = nil
+		}
+
+		return
+	}
+
+	var notAfter time.Time
+	if spec.SecretName != "" {
+		if slices.ContainsFunc(p.Spec.Roles, func(role v1alpha1.Role) bool { return role.CertificateAuthentication }) {
+			return errClientCertificatesNeedCA
+		}
+
+		notAfter, err = checkSecret(ctx, p)
+	} else {
+		notAfter, err = reconcileGenerated(ctx, p)
+	}
+	if err != nil {
+		return
+	}
+
+	status := p.Status.TLS
+	if status == nil || status.SecretName != SecretName(p) {
+		// members are restarted with the new Secret mounted. Files are mounted at the same path,
+		// thus a previously mounted certificate remains usable meanwhile.
+		status = &v1alpha1.TLSStatus{
+			SecretName: SecretName(p),
+			Mounted:    status != nil && status.Mounted,
+		}
+	} else if status.NotAfter != nil && status.NotAfter.Unix() != notAfter.Unix() {
+		ctx.Eventf(corev1.EventTypeNormal, "CertificateRenewed", "Server certificate in %s renewed, valid until %s", status.SecretName, notAfter.Format(time.RFC3339))
+
+		status.ReloadAfter = &metav1.Time{Time: time.Now().Add(reloadDelay)}
+	}
+
+	status.NotAfter = &metav1.Time{Time: notAfter}
+	p.Status.TLS = status
+
+	if status.ReloadAfter != nil && time.Now().After(status.ReloadAfter.Time) && p.Status.State == v1alpha1.PatroniPostgresStateReady {
+		if err = reloadMembers(ctx, p); err != nil {
+			return
+		}
+
+		status.ReloadAfter = nil
+	}
+
+	return
+}
+
+// CA returns the generated CA, which signs server and client certificates
+func CA(ctx context.Context, p *v1alpha1.PatroniPostgres) (ca *pki.KeyPair, err error) {
+	secret := &corev1.Secret{}
+	if err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: caSecretName(p)}, secret); err != nil {
+		return
+	}
+
+	return &pki.KeyPair{
+		Certificate: secret.Data[CAKey],
+		Key:         secret.Data[caKeyKey],
+	}, nil
+}
+
+// checkSecret validates a user supplied Secret, and returns expiry of its certificate
+func checkSecret(ctx context.Context, p *v1alpha1.PatroniPostgres) (notAfter time.Time, err error) {
+	secret := &corev1.Secret{}
+	if err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: SecretName(p)}, secret); err != nil {
+		return
+	}
+
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, CAKey} {
+		if len(secret.Data[key]) == 0 {
+			return notAfter, fmt.Errorf("secret %s has no %s", secret.Name, key)
+		}
+	}
+
+	cert, err := pki.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return notAfter, fmt.Errorf("secret %s: %w", secret.Name, err)
+	}
+
+	return cert.NotAfter, nil
+}
+
+// reconcileGenerated ensures a CA and a server certificate signed by it, renewing them before expiry.
+// Returns expiry of the server certificate.
+func reconcileGenerated(ctx context.Context, p *v1alpha1.PatroniPostgres) (notAfter time.Time, err error) {
+	spec := Spec(p)
+	renewBefore := spec.GetRenewBefore()
+
+	caSecret, err := getOrNewSecret(ctx, p, caSecretName(p), corev1.SecretTypeOpaque)
+	if err != nil {
+		return
+	}
+
+	ca := &pki.KeyPair{
+		Certificate: caSecret.Data[CAKey],
+		Key:         caSecret.Data[caKeyKey],
+	}
+
+	caRenewed := pki.NeedsRenewal(ca.Certificate, nil, nil, renewBefore)
+	if caRenewed {
+		if ca, err = pki.GenerateCA(fmt.Sprintf("%s.%s CA", p.Name, p.Namespace), caValidity); err != nil {
+			return
+		}
+
+		caSecret.Data = map[string][]byte{
+			CAKey:    ca.Certificate,
+			caKeyKey: ca.Key,
+		}
+
+		if err = saveSecret(ctx, caSecret); err != nil {
+			return
+		}
+	}
+
+	secret, err := getOrNewSecret(ctx, p, SecretName(p), corev1.SecretTypeTLS)
+	if err != nil {
+		return
+	}
+
+	if caRenewed || pki.NeedsRenewal(secret.Data[corev1.TLSCertKey], ca, DNSNames(p), renewBefore) {
+		var server *pki.KeyPair
+		if server, err = pki.GenerateServerCertificate(ca, p.Name, DNSNames(p), spec.GetCertificateValidity()); err != nil {
+			return
+		}
+
+		secret.Data = map[string][]byte{
+			corev1.TLSCertKey:       server.Certificate,
+			corev1.TLSPrivateKeyKey: server.Key,
+			CAKey:                   ca.Certificate,
+		}
+
+		if err = saveSecret(ctx, secret); err != nil {
+			return
+		}
+	}
+
+	cert, err := pki.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return
+	}
+
+	return cert.NotAfter, nil
+}
+
+// getOrNewSecret returns the named Secret, or a new one to be created
+func getOrNewSecret(ctx context.Context, p *v1alpha1.PatroniPostgres, name string, secretType corev1.SecretType) (secret *corev1.Secret, err error) {
+	secret = &corev1.Secret{}
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: name}, secret)
+	if errors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Type: secretType,
+		}
+
+		err = nil
+	}
+
+	return
+}
+
+// saveSecret creates or updates secret
+func saveSecret(ctx context.Context, secret *corev1.Secret) (err error) {
+	if err = ctx.SetMeta(secret); err != nil {
+		return
+	}
+
+	if secret.ResourceVersion == "" {
+		return ctx.Create(ctx, secret)
+	}
+
+	return ctx.Update(ctx, secret)
+}
+
+// reloadMembers makes members reload PostgreSQL configuration, thus use the renewed certificate
+func reloadMembers(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	pods, err := patroni.Pods(ctx, p)
+	if err != nil {
+		return
+	}
+
+	for idx := range pods {
+		if err = patroni.Reload(ctx, &pods[idx]); err != nil {
+			return
+		}
+	}
+
+	return
+}
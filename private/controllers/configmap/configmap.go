@@ -29,6 +29,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -61,6 +62,7 @@ const (
 	// during-upgrade annotations
 	configCMPrimaryInitdbArgs        = "primary-initdb-args"
 	configCMLatestCheckpointLocation = "latest-checkpoint-location"
+	configCMPreviousDBId             = "previous-initialize"
 )
 
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
@@ -135,10 +137,88 @@ func SetDBId(ctx context.Context, p *v1alpha1.PatroniPostgres, dbid string) (err
 		return
 	}
 
+	// Save previous DBID for aborting the upgrade
+	if _, ok := cm.ObjectMeta.Annotations[configCMPreviousDBId]; !ok {
+		cm.ObjectMeta.Annotations[configCMPreviousDBId] = cm.ObjectMeta.Annotations[configCMdbidAnnotation]
+	}
+
 	// Set DBID
 	cm.ObjectMeta.Annotations[configCMdbidAnnotation] = dbid
 
-	// Resume Patroni
+	if err = resume(cm); err != nil {
+		return
+	}
+
+	// update
+	err = ctx.Update(ctx, cm)
+
+	return
+}
+
+// SetInitialDBId sets Database system identifier of data prepared for a new cluster, thus Patroni
+// starts it instead of running initdb
+func SetInitialDBId(ctx context.Context, p *v1alpha1.PatroniPostgres, dbid string) (err error) {
+	cm, err := getConfigCM(ctx, p)
+	if err != nil {
+		return
+	}
+
+	if cm.ObjectMeta.Annotations == nil {
+		cm.ObjectMeta.Annotations = map[string]string{}
+	}
+
+	cm.ObjectMeta.Annotations[configCMdbidAnnotation] = dbid
+
+	err = ctx.Update(ctx, cm)
+
+	return
+}
+
+// SetInitialConfig sets Patroni dynamic configuration of a new cluster, before members are started
+func SetInitialConfig(ctx context.Context, p *v1alpha1.PatroniPostgres, config map[string]any) (err error) {
+	cm, err := getConfigCM(ctx, p)
+	if err != nil {
+		return
+	}
+
+	configb, err := json.Marshal(config)
+	if err != nil {
+		return
+	}
+
+	if cm.ObjectMeta.Annotations == nil {
+		cm.ObjectMeta.Annotations = map[string]string{}
+	}
+
+	cm.ObjectMeta.Annotations[configCMconfigAnnotation] = string(configb)
+
+	err = ctx.Update(ctx, cm)
+
+	return
+}
+
+// RestoreDBId restores Database system identifier after an aborted upgrade, and resumes Patroni
+func RestoreDBId(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	cm, err := getConfigCM(ctx, p)
+	if err != nil {
+		return
+	}
+
+	if dbid, ok := cm.ObjectMeta.Annotations[configCMPreviousDBId]; ok {
+		cm.ObjectMeta.Annotations[configCMdbidAnnotation] = dbid
+	}
+
+	if err = resume(cm); err != nil {
+		return
+	}
+
+	err = ctx.Update(ctx, cm)
+
+	return
+}
+
+// resume removes pause from Patroni configuration
+func resume(cm *corev1.ConfigMap) (err error) {
 	configs, ok := cm.ObjectMeta.Annotations[configCMconfigAnnotation]
 	if !ok {
 		return ErrNoConfigAnnotation
@@ -154,7 +234,41 @@ func SetDBId(ctx context.Context, p *v1alpha1.PatroniPostgres, dbid string) (err
 	}
 	cm.ObjectMeta.Annotations[configCMconfigAnnotation] = string(configb)
 
-	// update
+	return
+}
+
+// UpdateConfig updates Patroni dynamic configuration using fn. Returns ErrNoConfigAnnotation
+// if Patroni has not bootstrapped the cluster yet.
+func UpdateConfig(ctx context.Context, p *v1alpha1.PatroniPostgres, fn func(config map[string]any)) (err error) {
+	cm, err := getConfigCM(ctx, p)
+	if err != nil {
+		return
+	}
+
+	configs, ok := cm.ObjectMeta.Annotations[configCMconfigAnnotation]
+	if !ok {
+		return ErrNoConfigAnnotation
+	}
+	var orig, config map[string]any
+	if err = json.Unmarshal([]byte(configs), &orig); err != nil {
+		return
+	}
+	if err = json.Unmarshal([]byte(configs), &config); err != nil {
+		return
+	}
+
+	fn(config)
+
+	if reflect.DeepEqual(orig, config) {
+		return
+	}
+
+	configb, err := json.Marshal(config)
+	if err != nil {
+		return
+	}
+	cm.ObjectMeta.Annotations[configCMconfigAnnotation] = string(configb)
+
 	err = ctx.Update(ctx, cm)
 
 	return
@@ -223,6 +337,7 @@ func ClearUpgradeAnnotations(ctx context.Context, p *v1alpha1.PatroniPostgres) (
 
 	delete(cm.ObjectMeta.Annotations, configCMPrimaryInitdbArgs)
 	delete(cm.ObjectMeta.Annotations, configCMLatestCheckpointLocation)
+	delete(cm.ObjectMeta.Annotations, configCMPreviousDBId)
 
 	err = ctx.Update(ctx, cm)
 
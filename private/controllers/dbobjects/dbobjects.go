@@ -0,0 +1,259 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package dbobjects
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/certificate"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/postgres"
+)
+
+const (
+	maintenanceDatabase = "postgres"
+)
+
+var (
+	reservedRoles = []string{
+		statefulset.PatroniSuperuserUsername,
+		statefulset.PatroniReplicationUsername,
+		statefulset.MonitoringUsername,
+	}
+)
+
+// Reconcile reconciles roles and databases on the primary
+func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if len(p.Spec.Roles)+len(p.Spec.Databases)+len(p.Status.ManagedRoles)+len(p.Status.ManagedDatabases) == 0 {
+		return
+	}
+
+	// primary must be reachable, and writable
+	if p.Status.State != v1alpha1.PatroniPostgresStateReady || p.Status.Standby {
+		return
+	}
+
+	for idx := range p.Spec.Roles {
+		if slices.Contains(reservedRoles, p.Spec.Roles[idx].Name) {
+			return fmt.Errorf("role %s is reserved", p.Spec.Roles[idx].Name)
+		}
+	}
+
+	conn, err := postgres.Connect(ctx, p, maintenanceDatabase)
+	if err != nil {
+		return
+	}
+	defer conn.Close(ctx)
+
+	roles := make([]string, 0, len(p.Spec.Roles))
+	for idx := range p.Spec.Roles {
+		role := &p.Spec.Roles[idx]
+
+		if err = reconcileRole(ctx, conn, p, role); err != nil {
+			return
+		}
+
+		roles = append(roles, role.Name)
+	}
+
+	databases := make([]string, 0, len(p.Spec.Databases))
+	for idx := range p.Spec.Databases {
+		database := &p.Spec.Databases[idx]
+
+		if err = reconcileDatabase(ctx, conn, database); err != nil {
+			return
+		}
+
+		databases = append(databases, database.Name)
+	}
+
+	if p.Spec.DropPolicy == v1alpha1.DropPolicyDrop {
+		// databases first, as they may be owned by roles to be dropped
+		for _, database := range p.Status.ManagedDatabases {
+			if slices.Contains(databases, database) {
+				continue
+			}
+
+			if _, err = conn.Exec(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s", postgres.QuoteIdentifier(database))); err != nil {
+				return
+			}
+		}
+
+		for _, role := range p.Status.ManagedRoles {
+			if slices.Contains(roles, role) {
+				continue
+			}
+
+			if _, err = conn.Exec(ctx, fmt.Sprintf("DROP ROLE IF EXISTS %s", postgres.QuoteIdentifier(role))); err != nil {
+				return
+			}
+
+			if err = secret.DeleteRoleSecret(ctx, p, role); err != nil {
+				return
+			}
+		}
+	}
+
+	p.Status.ManagedRoles = roles
+	p.Status.ManagedDatabases = databases
+
+	return
+}
+
+func reconcileRole(ctx context.Context, conn *pgx.Conn, p *v1alpha1.PatroniPostgres, role *v1alpha1.Role) (err error) {
+	password, generated, err := secret.ReconcileRoleSecret(ctx, p, role.Name)
+	if err != nil {
+		return
+	}
+
+	if err = reconcileRoleCertificate(ctx, p, role); err != nil {
+		return
+	}
+
+	var exists bool
+	if err = conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = $1)", role.Name).Scan(&exists); err != nil {
+		return
+	}
+
+	options := roleOptions(role)
+	if !exists || generated {
+		options = append(options, "PASSWORD", postgres.QuoteLiteral(password))
+	}
+
+	verb := "ALTER"
+	if !exists {
+		verb = "CREATE"
+	}
+
+	if _, err = conn.Exec(ctx, fmt.Sprintf("%s ROLE %s WITH %s", verb, postgres.QuoteIdentifier(role.Name), strings.Join(options, " "))); err != nil {
+		return
+	}
+
+	return reconcileMemberships(ctx, conn, role)
+}
+
+// reconcileRoleCertificate issues a client certificate for roles using certificate authentication
+func reconcileRoleCertificate(ctx context.Context, p *v1alpha1.PatroniPostgres, role *v1alpha1.Role) (err error) {
+	if !role.CertificateAuthentication {
+		return secret.ReconcileRoleCertificate(ctx, p, role.Name, nil, 0, 0)
+	}
+
+	ca, err := certificate.CA(ctx, p)
+	if err != nil {
+		return
+	}
+
+	spec := certificate.Spec(p)
+
+	return secret.ReconcileRoleCertificate(ctx, p, role.Name, ca, spec.GetCertificateValidity(), spec.GetRenewBefore())
+}
+
+func roleOptions(role *v1alpha1.Role) []string {
+	options := []string{"NOLOGIN", "NOCREATEDB", "NOCREATEROLE"}
+
+	if role.GetLogin() {
+		options[0] = "LOGIN"
+	}
+	if role.CreateDB {
+		options[1] = "CREATEDB"
+	}
+	if role.CreateRole {
+		options[2] = "CREATEROLE"
+	}
+
+	return append(options, "CONNECTION LIMIT", fmt.Sprintf("%d", role.GetConnectionLimit()))
+}
+
+func reconcileMemberships(ctx context.Context, conn *pgx.Conn, role *v1alpha1.Role) (err error) {
+	rows, err := conn.Query(ctx, `SELECT DISTINCT g.rolname
+		FROM pg_catalog.pg_auth_members m
+		JOIN pg_catalog.pg_roles g ON g.oid = m.roleid
+		JOIN pg_catalog.pg_roles r ON r.oid = m.member
+		WHERE r.rolname = $1`, role.Name)
+	if err != nil {
+		return
+	}
+
+	current, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return
+	}
+
+	qrole := postgres.QuoteIdentifier(role.Name)
+
+	for _, group := range role.MemberOf {
+		if slices.Contains(current, group) {
+			continue
+		}
+
+		if _, err = conn.Exec(ctx, fmt.Sprintf("GRANT %s TO %s", postgres.QuoteIdentifier(group), qrole)); err != nil {
+			return
+		}
+	}
+
+	for _, group := range current {
+		if slices.Contains(role.MemberOf, group) {
+			continue
+		}
+
+		if _, err = conn.Exec(ctx, fmt.Sprintf("REVOKE %s FROM %s", postgres.QuoteIdentifier(group), qrole)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func reconcileDatabase(ctx context.Context, conn *pgx.Conn, database *v1alpha1.Database) (err error) {
+	owner := database.Owner
+	if owner == "" {
+		owner = statefulset.PatroniSuperuserUsername
+	}
+
+	var currentOwner string
+	err = conn.QueryRow(ctx, "SELECT pg_catalog.pg_get_userbyid(datdba) FROM pg_catalog.pg_database WHERE datname = $1", database.Name).Scan(&currentOwner)
+	if errors.Is(err, pgx.ErrNoRows) {
+		_, err = conn.Exec(ctx, fmt.Sprintf("CREATE DATABASE %s OWNER %s", postgres.QuoteIdentifier(database.Name), postgres.QuoteIdentifier(owner)))
+
+		return
+	}
+
+	if err != nil || currentOwner == owner {
+		return
+	}
+
+	_, err = conn.Exec(ctx, fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", postgres.QuoteIdentifier(database.Name), postgres.QuoteIdentifier(owner)))
+
+	return
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package members
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/patroni"
+)
+
+// Reconcile collects members' state from Patroni into status
+func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	pods, err := patroni.Pods(ctx, p)
+	if err != nil {
+		return
+	}
+
+	previousLeader := p.Status.Leader
+
+	p.Status.Members = nil
+	p.Status.Leader = ""
+	p.Status.MaxLag = nil
+
+	cluster, err := patroni.GetCluster(ctx, pods)
+	if err != nil {
+		// no member is reachable, e.g. during startup
+		return nil
+	}
+
+	for idx := range cluster.Members {
+		member := &cluster.Members[idx]
+
+		status := v1alpha1.MemberStatus{
+			Name:           member.Name,
+			Role:           member.Role,
+			State:          member.State,
+			Timeline:       member.Timeline,
+			PendingRestart: member.PendingRestart,
+		}
+
+		switch member.Role {
+		case patroni.RoleLeader, patroni.RoleStandbyLeader:
+			p.Status.Leader = member.Name
+		default:
+			if lag, ok := member.LagBytes(); ok {
+				status.Lag = &lag
+
+				if p.Status.MaxLag == nil || *p.Status.MaxLag < lag {
+					p.Status.MaxLag = &lag
+				}
+			}
+		}
+
+		p.Status.Members = append(p.Status.Members, status)
+	}
+
+	if previousLeader != "" && p.Status.Leader != "" && p.Status.Leader != previousLeader {
+		ctx.Eventf(corev1.EventTypeNormal, "LeaderChanged", "Leader changed from %s to %s", previousLeader, p.Status.Leader)
+	}
+
+	return
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package monitoring
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/postgres"
+)
+
+const (
+	maintenanceDatabase = "postgres"
+
+	// monitoringConnectionLimit limits connections of the monitoring role
+	monitoringConnectionLimit = 5
+)
+
+var (
+	serviceMonitorGVK = schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    "ServiceMonitor",
+	}
+)
+
+// Reconcile handles monitoring role, metrics Service and ServiceMonitor
+func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if err = reconcileRole(ctx, p); err != nil {
+		return
+	}
+
+	if err = reconcileService(ctx, p); err != nil {
+		return
+	}
+
+	return reconcileServiceMonitor(ctx, p)
+}
+
+// MetricsServiceName returns name of Service exposing exporters
+func MetricsServiceName(p *v1alpha1.PatroniPostgres) string {
+	return fmt.Sprintf("%s-metrics", p.Name)
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update;delete
+
+// reconcileRole ensures monitoring role's secret exists, and the role is created on the primary
+// with pg_monitor privileges. Without monitoring, the role is dropped, and its secret is removed.
+func reconcileRole(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if p.Spec.Monitoring == nil {
+		return dropRole(ctx, p)
+	}
+
+	password, generated, err := secret.ReconcileRoleSecret(ctx, p, statefulset.MonitoringUsername)
+	if err != nil {
+		return
+	}
+
+	// primary must be reachable, and writable
+	if p.Status.State != v1alpha1.PatroniPostgresStateReady || p.Status.Standby {
+		return
+	}
+
+	conn, err := postgres.Connect(ctx, p, maintenanceDatabase)
+	if err != nil {
+		return
+	}
+	defer conn.Close(ctx)
+
+	var exists bool
+	if err = conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = $1)", statefulset.MonitoringUsername).Scan(&exists); err != nil {
+		return
+	}
+
+	qrole := postgres.QuoteIdentifier(statefulset.MonitoringUsername)
+
+	if !exists {
+		_, err = conn.Exec(ctx, fmt.Sprintf("CREATE ROLE %s WITH LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE CONNECTION LIMIT %d PASSWORD %s IN ROLE pg_monitor",
+			qrole, monitoringConnectionLimit, postgres.QuoteLiteral(password)))
+	} else if generated {
+		_, err = conn.Exec(ctx, fmt.Sprintf("ALTER ROLE %s WITH PASSWORD %s", qrole, postgres.QuoteLiteral(password)))
+	}
+
+	return
+}
+
+// dropRole drops monitoring role, once it is not needed anymore. Its secret marks that the role exists.
+func dropRole(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: secret.RoleName(p, statefulset.MonitoringUsername)}, &corev1.Secret{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	// primary must be reachable, and writable
+	if p.Status.State != v1alpha1.PatroniPostgresStateReady || p.Status.Standby {
+		return
+	}
+
+	conn, err := postgres.Connect(ctx, p, maintenanceDatabase)
+	if err != nil {
+		return
+	}
+	defer conn.Close(ctx)
+
+	if _, err = conn.Exec(ctx, fmt.Sprintf("DROP ROLE IF EXISTS %s", postgres.QuoteIdentifier(statefulset.MonitoringUsername))); err != nil {
+		return
+	}
+
+	return secret.DeleteRoleSecret(ctx, p, statefulset.MonitoringUsername)
+}
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;create;update;delete
+
+// reconcileService handles <name>-metrics Service selecting all members' exporters
+func reconcileService(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	service := &corev1.Service{}
+	var create bool
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: MetricsServiceName(p)}, service)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return
+		}
+
+		if p.Spec.Monitoring == nil {
+			return nil
+		}
+
+		service = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: MetricsServiceName(p),
+			},
+		}
+
+		create = true
+	} else if p.Spec.Monitoring == nil {
+		if err = ctx.Delete(ctx, service); errors.IsNotFound(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	if err = ctx.SetMeta(service); err != nil {
+		return
+	}
+
+	service.Labels = ctx.PodLabels(context.ComponentMetrics)
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.Selector = ctx.PodLabels(context.ComponentPostgres)
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       statefulset.ExporterPortName,
+			Port:       statefulset.ExporterPort,
+			TargetPort: intstr.FromInt(statefulset.ExporterPort),
+		},
+	}
+
+	if create {
+		err = ctx.Create(ctx, service)
+	} else {
+		err = ctx.Update(ctx, service)
+	}
+
+	return
+}
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;update;delete
+
+// reconcileServiceMonitor handles ServiceMonitor for metrics Service, if Prometheus Operator is installed
+func reconcileServiceMonitor(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if _, err = ctx.RESTMapper().RESTMapping(serviceMonitorGVK.GroupKind(), serviceMonitorGVK.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(serviceMonitorGVK)
+	var create bool
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: MetricsServiceName(p)}, sm)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return
+		}
+
+		if p.Spec.Monitoring == nil {
+			return nil
+		}
+
+		sm.SetName(MetricsServiceName(p))
+
+		create = true
+	} else if p.Spec.Monitoring == nil {
+		if err = ctx.Delete(ctx, sm); errors.IsNotFound(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	if err = ctx.SetMeta(sm); err != nil {
+		return
+	}
+
+	labels := sm.GetLabels()
+	for k, v := range p.Spec.Monitoring.ServiceMonitor.Labels {
+		labels[k] = v
+	}
+	sm.SetLabels(labels)
+
+	matchLabels := map[string]any{}
+	for k, v := range ctx.PodLabels(context.ComponentMetrics) {
+		matchLabels[k] = v
+	}
+
+	endpoint := map[string]any{
+		"port": statefulset.ExporterPortName,
+	}
+	if p.Spec.Monitoring.ServiceMonitor.Interval != "" {
+		endpoint["interval"] = p.Spec.Monitoring.ServiceMonitor.Interval
+	}
+
+	sm.Object["spec"] = map[string]any{
+		"selector": map[string]any{
+			"matchLabels": matchLabels,
+		},
+		"endpoints": []any{endpoint},
+	}
+
+	if create {
+		err = ctx.Create(ctx, sm)
+	} else {
+		err = ctx.Update(ctx, sm)
+	}
+
+	return
+}
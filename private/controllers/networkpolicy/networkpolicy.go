@@ -33,8 +33,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/bootstrap"
 	"github.com/k-web-s/patroni-postgres-operator/private/context"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/service"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/operator"
+	"github.com/k-web-s/patroni-postgres-operator/private/patroni"
 )
 
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;create;update
@@ -65,6 +69,7 @@ func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
 	}
 
 	port := intstr.FromInt(service.PostgresPort)
+	patroniPort := intstr.FromInt(patroni.APIPort)
 	policy.Spec = networking.NetworkPolicySpec{
 		PodSelector: v1.LabelSelector{
 			MatchLabels: ctx.CommonLabels(),
@@ -89,8 +94,64 @@ func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
 					},
 				},
 			},
+			{
+				// Operator accessing PostgreSQL and Patroni API
+				From: []networking.NetworkPolicyPeer{
+					operator.NetworkPolicyPeer(),
+				},
+				Ports: []networking.NetworkPolicyPort{
+					{
+						Port: &port,
+					},
+					{
+						Port: &patroniPort,
+					},
+				},
+			},
 		},
 	}
+
+	if p.Spec.Monitoring != nil {
+		exporterPort := intstr.FromInt(statefulset.ExporterPort)
+		policy.Spec.Ingress = append(policy.Spec.Ingress, networking.NetworkPolicyIngressRule{
+			// postgres_exporter
+			From: p.Spec.Monitoring.AccessControl,
+			Ports: []networking.NetworkPolicyPort{
+				{
+					Port: &exporterPort,
+				},
+			},
+		})
+	}
+
+	// clusters being cloned from this one, while bootstrapping
+	clones, err := bootstrap.Cloning(ctx, p)
+	if err != nil {
+		return
+	}
+
+	for _, clone := range clones {
+		policy.Spec.Ingress = append(policy.Spec.Ingress, networking.NetworkPolicyIngressRule{
+			From: []networking.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &v1.LabelSelector{
+						MatchLabels: map[string]string{
+							"kubernetes.io/metadata.name": clone.Namespace,
+						},
+					},
+					PodSelector: &v1.LabelSelector{
+						MatchLabels: context.ClusterLabels(&clone),
+					},
+				},
+			},
+			Ports: []networking.NetworkPolicyPort{
+				{
+					Port: &port,
+				},
+			},
+		})
+	}
+
 	policy.Spec.Ingress = append(policy.Spec.Ingress, p.Spec.AdditionalNetworkPolicyIngress...)
 
 	if create {
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package patroniconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/backup"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/certificate"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/patroni"
+	"github.com/k-web-s/patroni-postgres-operator/private/postgres"
+)
+
+const (
+	postgresqlKey = "postgresql"
+	parametersKey = "parameters"
+	pgHbaKey      = "pg_hba"
+
+	recoveryConfKey = "recovery_conf"
+
+	pgHbaAll = "all"
+)
+
+var (
+	// requiredPgHba lists entries the cluster needs to operate. Local connections are
+	// used by Patroni and pg_upgrade, while the superuser is used by the operator
+	// and upgrade jobs.
+	requiredPgHba = []string{
+		"local all all trust",
+		"host replication " + statefulset.PatroniReplicationUsername + " all md5",
+		"host all " + statefulset.PatroniSuperuserUsername + " all md5",
+	}
+
+	// monitoringPgHba lets postgres_exporter connect over TCP to the local member
+	monitoringPgHba = []string{
+		"host all " + statefulset.MonitoringUsername + " 127.0.0.1/32 md5",
+		"host all " + statefulset.MonitoringUsername + " ::1/128 md5",
+	}
+
+	// defaultPgHba allows password authentication, when only certificate authentication is configured
+	defaultPgHba = "host all all all md5"
+)
+
+// Reconcile pushes PostgreSQL configuration into Patroni dynamic configuration
+func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if p.Status.State != v1alpha1.PatroniPostgresStateReady {
+		return
+	}
+
+	parameters := maps.Clone(p.Spec.Postgresql.Parameters)
+
+	// without the certificate mounted, PostgreSQL would leave ssl off on reload
+	if certificate.Spec(p) != nil && certificate.Mounted(p) {
+		if parameters == nil {
+			parameters = map[string]string{}
+		}
+
+		maps.Copy(parameters, certificate.Parameters())
+	}
+
+	// a standby cluster starts archiving once promoted
+	archiving := p.Spec.Backup != nil && !p.Status.Standby
+	if archiving {
+		if parameters == nil {
+			parameters = map[string]string{}
+		}
+
+		maps.Copy(parameters, backup.ClusterRepository(p).Parameters())
+	}
+
+	pgHba := p.Spec.Postgresql.PgHba
+	certRoles := certificateRoles(p)
+	manageHba := len(pgHba)+len(certRoles) > 0
+
+	if len(parameters)+len(p.Status.ManagedParameters) > 0 || manageHba || p.Status.PgHbaManaged {
+		if err = validateParameters(ctx, p); err != nil {
+			return
+		}
+
+		previous := maps.Clone(p.Status.PreviousParameters)
+
+		err = configmap.UpdateConfig(ctx, p, func(config map[string]any) {
+			dcsPostgresql := section(config, postgresqlKey)
+			dcsParameters := section(dcsPostgresql, parametersKey)
+
+			for _, name := range p.Status.ManagedParameters {
+				if _, ok := parameters[name]; !ok {
+					restoreParameter(dcsParameters, previous, name)
+				}
+			}
+
+			for name, value := range parameters {
+				if !slices.Contains(p.Status.ManagedParameters, name) {
+					recordParameter(dcsParameters, &previous, name)
+				}
+
+				dcsParameters[name] = value
+			}
+
+			// restore_command is a recovery parameter for Patroni
+			recoveryConf := section(dcsPostgresql, recoveryConfKey)
+			if archiving {
+				recoveryConf[backup.RestoreCommandKey] = backup.ClusterRepository(p).RestoreCommand()
+			} else {
+				delete(recoveryConf, backup.RestoreCommandKey)
+			}
+			if len(recoveryConf) == 0 {
+				delete(dcsPostgresql, recoveryConfKey)
+			}
+
+			if manageHba {
+				dcsPostgresql[pgHbaKey] = renderPgHba(pgHba, certRoles, p.Spec.Monitoring != nil)
+			} else {
+				delete(dcsPostgresql, pgHbaKey)
+			}
+		})
+		if errors.Is(err, configmap.ErrNoConfigAnnotation) {
+			return nil
+		}
+		if err != nil {
+			return
+		}
+
+		p.Status.ManagedParameters = slices.Sorted(maps.Keys(parameters))
+		p.Status.PreviousParameters = previous
+		p.Status.PgHbaManaged = manageHba
+	}
+
+	return updatePendingRestart(ctx, p)
+}
+
+// recordParameter saves the value of a parameter set before the operator takes it over
+func recordParameter(dcsParameters map[string]any, previous *map[string]string, name string) {
+	value, ok := dcsParameters[name]
+	if !ok {
+		return
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	if *previous == nil {
+		*previous = map[string]string{}
+	}
+
+	(*previous)[name] = string(encoded)
+}
+
+// restoreParameter restores the value a parameter had before the operator took it over, or
+// removes it if it was not set
+func restoreParameter(dcsParameters map[string]any, previous map[string]string, name string) {
+	delete(dcsParameters, name)
+
+	encoded, ok := previous[name]
+	if !ok {
+		return
+	}
+
+	delete(previous, name)
+
+	var value any
+	if err := json.Unmarshal([]byte(encoded), &value); err == nil {
+		dcsParameters[name] = value
+	}
+}
+
+func validateParameters(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if len(p.Spec.Postgresql.Parameters) == 0 {
+		return
+	}
+
+	conn, err := postgres.Connect(ctx, p, "postgres")
+	if err != nil {
+		return
+	}
+	defer conn.Close(ctx)
+
+	for _, name := range slices.Sorted(maps.Keys(p.Spec.Postgresql.Parameters)) {
+		if err = postgres.ValidateParameterForVersion(name, p.Status.Version); err != nil {
+			return
+		}
+
+		if err = postgres.ValidateParameter(ctx, conn, name, p.Spec.Postgresql.Parameters[name]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// certificateRoles returns roles using certificate authentication
+func certificateRoles(p *v1alpha1.PatroniPostgres) (roles []string) {
+	for _, role := range p.Spec.Roles {
+		if role.CertificateAuthentication {
+			roles = append(roles, role.Name)
+		}
+	}
+
+	return
+}
+
+// renderPgHba renders pg_hba.conf lines, in a list Patroni expects. Entries for postgres_exporter
+// and roles using certificate authentication come first, followed by rules, or defaultPgHba if
+// no rules are given.
+func renderPgHba(rules []v1alpha1.PgHbaRule, certRoles []string, monitoring bool) []any {
+	lines := make([]any, 0, len(requiredPgHba)+len(monitoringPgHba)+len(certRoles)+len(rules)+1)
+	for _, line := range requiredPgHba {
+		lines = append(lines, line)
+	}
+
+	if monitoring {
+		for _, line := range monitoringPgHba {
+			lines = append(lines, line)
+		}
+	}
+
+	for _, role := range certRoles {
+		lines = append(lines, "hostssl all "+role+" all cert")
+	}
+
+	if len(rules) == 0 {
+		lines = append(lines, defaultPgHba)
+	}
+
+	for _, rule := range rules {
+		connType := rule.Type
+		if connType == "" {
+			connType = "host"
+		}
+
+		fields := []string{connType, orAll(rule.Database), orAll(rule.User)}
+		if connType != "local" {
+			fields = append(fields, orAll(rule.Address))
+		}
+		fields = append(fields, rule.Method)
+		if rule.Options != "" {
+			fields = append(fields, rule.Options)
+		}
+
+		lines = append(lines, strings.Join(fields, " "))
+	}
+
+	return lines
+}
+
+func orAll(s string) string {
+	if s == "" {
+		return pgHbaAll
+	}
+
+	return s
+}
+
+// updatePendingRestart collects parameters pending restart as reported by Patroni
+func updatePendingRestart(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	pods, err := patroni.Pods(ctx, p)
+	if err != nil {
+		return
+	}
+
+	pending := map[string]struct{}{}
+	for idx := range pods {
+		status, err := patroni.GetMemberStatus(ctx, &pods[idx])
+		if err != nil {
+			// member may be restarting
+			continue
+		}
+
+		for name := range status.PendingRestartReason {
+			pending[name] = struct{}{}
+		}
+	}
+
+	p.Status.PendingRestart = slices.Sorted(maps.Keys(pending))
+
+	return
+}
+
+// section returns a subsection of config, creating it if missing
+func section(config map[string]any, key string) map[string]any {
+	sub, ok := config[key].(map[string]any)
+	if !ok {
+		sub = map[string]any{}
+		config[key] = sub
+	}
+
+	return sub
+}
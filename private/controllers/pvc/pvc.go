@@ -116,9 +116,20 @@ func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
 
 	propagation := metav1.DeletePropagationBackground
 	for _, pvc := range existingPVCMap {
-		if err = ctx.Delete(ctx, pvc, &client.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !errors.IsNotFound(err) {
+		if pvc.DeletionTimestamp != nil {
+			// already being deleted
+			continue
+		}
+
+		if err = ctx.Delete(ctx, pvc, &client.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
 			return err
 		}
+
+		ctx.Eventf(corev1.EventTypeNormal, "VolumeDeleted", "Deleted PersistentVolumeClaim %s of removed member", pvc.Name)
 	}
 
 	return nil
@@ -26,8 +26,12 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package secret
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -36,11 +40,20 @@ import (
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
 	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/pki"
 )
 
 const (
 	SuperUserPasswordKey       = "superuser-password"
 	ReplicationUserPasswordKey = "replication-password"
+
+	RoleUsernameKey = "username"
+	RolePasswordKey = "password"
+
+	// Client certificate of roles using certificate authentication
+	RoleCertificateKey   = corev1.TLSCertKey
+	RolePrivateKeyKey    = corev1.TLSPrivateKeyKey
+	RoleCACertificateKey = "ca.crt"
 )
 
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update
@@ -82,6 +95,130 @@ func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
 	return
 }
 
+// SuperuserPassword returns superuser password
+func SuperuserPassword(ctx context.Context, p *v1alpha1.PatroniPostgres) (password string, err error) {
+	secret := &corev1.Secret{}
+
+	if err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: Name(p)}, secret); err != nil {
+		return
+	}
+
+	password = string(secret.Data[SuperUserPasswordKey])
+	if password == "" {
+		err = fmt.Errorf("secret %s has no %s", secret.Name, SuperUserPasswordKey)
+	}
+
+	return
+}
+
+// ReconcileRoleSecret ensures a secret holding role's credentials exists.
+// Returns role's password, and whether it has just been generated.
+func ReconcileRoleSecret(ctx context.Context, p *v1alpha1.PatroniPostgres, role string) (password string, generated bool, err error) {
+	secret := &corev1.Secret{}
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: RoleName(p, role)}, secret)
+	if err == nil {
+		if password = string(secret.Data[RolePasswordKey]); password != "" {
+			return
+		}
+	} else {
+		if !errors.IsNotFound(err) {
+			return
+		}
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: RoleName(p, role),
+			},
+		}
+	}
+
+	password = genSecret()
+	secret.StringData = map[string]string{
+		RoleUsernameKey: role,
+		RolePasswordKey: password,
+	}
+
+	if err = ctx.SetMeta(secret); err != nil {
+		return
+	}
+
+	if secret.ResourceVersion == "" {
+		err = ctx.Create(ctx, secret)
+	} else {
+		err = ctx.Update(ctx, secret)
+	}
+
+	generated = err == nil
+
+	return
+}
+
+// ReconcileRoleCertificate ensures role's secret holds a client certificate signed by ca, renewing
+// it renewBefore its expiry, or when ca changes. The certificate is removed if ca is nil.
+func ReconcileRoleCertificate(ctx context.Context, p *v1alpha1.PatroniPostgres, role string, ca *pki.KeyPair, validity, renewBefore time.Duration) (err error) {
+	secret := &corev1.Secret{}
+
+	if err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: RoleName(p, role)}, secret); err != nil {
+		return
+	}
+
+	if ca == nil {
+		if _, ok := secret.Data[RoleCertificateKey]; !ok {
+			return
+		}
+
+		delete(secret.Data, RoleCertificateKey)
+		delete(secret.Data, RolePrivateKeyKey)
+		delete(secret.Data, RoleCACertificateKey)
+
+		return ctx.Update(ctx, secret)
+	}
+
+	if !pki.NeedsRenewal(secret.Data[RoleCertificateKey], ca, nil, renewBefore) && bytes.Equal(secret.Data[RoleCACertificateKey], ca.Certificate) {
+		return
+	}
+
+	cert, err := pki.GenerateClientCertificate(ca, role, validity)
+	if err != nil {
+		return
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	secret.Data[RoleCertificateKey] = cert.Certificate
+	secret.Data[RolePrivateKeyKey] = cert.Key
+	secret.Data[RoleCACertificateKey] = ca.Certificate
+
+	if err = ctx.Update(ctx, secret); err != nil {
+		return
+	}
+
+	ctx.Eventf(corev1.EventTypeNormal, "ClientCertificateIssued", "Client certificate for role %s issued into %s", role, secret.Name)
+
+	return
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=delete
+
+// DeleteRoleSecret removes secret holding role's credentials
+func DeleteRoleSecret(ctx context.Context, p *v1alpha1.PatroniPostgres, role string) (err error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      RoleName(p, role),
+		},
+	}
+
+	if err = ctx.Delete(ctx, secret); errors.IsNotFound(err) {
+		err = nil
+	}
+
+	return
+}
+
 // Name returns name for secret
 func Name(p *v1alpha1.PatroniPostgres) string {
 	return p.Name
@@ -94,3 +231,8 @@ func genSecret() string {
 
 	return base64.StdEncoding.EncodeToString(key)
 }
+
+// RoleName returns name for secret holding role's credentials
+func RoleName(p *v1alpha1.PatroniPostgres, role string) string {
+	return fmt.Sprintf("%s-role-%s", p.Name, strings.ReplaceAll(role, "_", "-"))
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package service
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/patroni"
+)
+
+const (
+	PatroniPodRole_Replica = "replica"
+
+	// ReplicaEligibleLabel marks replicas selected by replica service
+	ReplicaEligibleLabel = "patronipostgres.kwebs.cloud/replica-eligible"
+	replicaEligibleValue = "true"
+)
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;create;update;delete
+
+// ReconcileReplicaService handles replica service, and labels replicas eligible to be selected by it
+func ReconcileReplicaService(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	serviceName := ReplicaServiceName(p)
+	service := &corev1.Service{}
+	var create bool
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: serviceName}, service)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return
+		}
+
+		if p.Spec.ReplicaService == nil {
+			return nil
+		}
+
+		service = &corev1.Service{
+			ObjectMeta: v1.ObjectMeta{
+				Name: serviceName,
+			},
+		}
+
+		create = true
+	} else if p.Spec.ReplicaService == nil {
+		if err = ctx.Delete(ctx, service); errors.IsNotFound(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	if err = ctx.SetMeta(service); err != nil {
+		return
+	}
+
+	service.Spec.Type = p.Spec.ReplicaService.ServiceType
+	service.Spec.Selector = ctx.PodLabels(context.ComponentPostgres)
+	service.Spec.Selector[PatroniPodRoleKey] = PatroniPodRole_Replica
+	service.Spec.Selector[ReplicaEligibleLabel] = replicaEligibleValue
+
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       PostgresPortName,
+			Port:       PostgresPort,
+			TargetPort: intstr.FromInt(PostgresPort),
+		},
+	}
+
+	if create {
+		err = ctx.Create(ctx, service)
+	} else {
+		err = ctx.Update(ctx, service)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return labelReplicas(ctx, p)
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list;patch
+
+// labelReplicas sets ReplicaEligibleLabel on PODs according to members' state
+func labelReplicas(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	// cluster may be starting up, keep current labels
+	if len(p.Status.Members) == 0 {
+		return
+	}
+
+	pods, err := patroni.Pods(ctx, p)
+	if err != nil {
+		return
+	}
+
+	for idx := range pods {
+		pod := &pods[idx]
+
+		eligible := "false"
+		if replicaEligible(p.Spec.ReplicaService, member(p, pod.Name)) {
+			eligible = replicaEligibleValue
+		}
+
+		if pod.Labels[ReplicaEligibleLabel] == eligible {
+			continue
+		}
+
+		orig := pod.DeepCopy()
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[ReplicaEligibleLabel] = eligible
+
+		if err = ctx.Patch(ctx, pod, client.MergeFrom(orig)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func replicaEligible(rs *v1alpha1.ReplicaService, member *v1alpha1.MemberStatus) bool {
+	if member == nil {
+		return false
+	}
+
+	if member.Role != patroni.RoleReplica && member.Role != patroni.RoleSyncStandby {
+		return false
+	}
+
+	if member.State != patroni.StateStreaming && member.State != patroni.StateRunning {
+		return false
+	}
+
+	if member.Lag == nil {
+		return false
+	}
+
+	return rs.MaxLagBytes == nil || *member.Lag <= *rs.MaxLagBytes
+}
+
+func member(p *v1alpha1.PatroniPostgres, name string) *v1alpha1.MemberStatus {
+	for idx := range p.Status.Members {
+		if p.Status.Members[idx].Name == name {
+			return &p.Status.Members[idx]
+		}
+	}
+
+	return nil
+}
+
+// ReplicaServiceName returns name of replica service
+func ReplicaServiceName(p *v1alpha1.PatroniPostgres) string {
+	return fmt.Sprintf("%s-repl", p.Name)
+}
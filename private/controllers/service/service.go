@@ -52,7 +52,11 @@ const (
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;create;update
 
 func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
-	return ReconcileService(ctx, p)
+	if err = ReconcileService(ctx, p); err != nil {
+		return
+	}
+
+	return ReconcileReplicaService(ctx, p)
 }
 
 func ReconcileService(ctx context.Context, p *v1alpha1.PatroniPostgres, patches ...Patch) (err error) {
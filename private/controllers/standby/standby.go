@@ -0,0 +1,143 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package standby
+
+import (
+	"encoding/json"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/backup"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
+)
+
+const (
+	standbyClusterKey  = "standby_cluster"
+	synchronousModeKey = "synchronous_mode"
+	basebackupMethod   = "basebackup"
+)
+
+var (
+	errNoSource = errors.New("spec.standby requires host or archive")
+)
+
+// standbyCluster is Patroni's standby_cluster section
+type standbyCluster struct {
+	Host                 string   `json:"host,omitempty"`
+	Port                 int32    `json:"port,omitempty"`
+	PrimarySlotName      string   `json:"primary_slot_name,omitempty"`
+	CreateReplicaMethods []string `json:"create_replica_methods,omitempty"`
+	RestoreCommand       string   `json:"restore_command,omitempty"`
+}
+
+// Reconcile keeps Patroni's standby_cluster section in sync with spec.standby, and promotes
+// the cluster once spec.standby is removed
+func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if !p.Status.Standby {
+		// refused by validation, other reconcilers still proceed
+		if p.Spec.Standby != nil {
+			ctx.Eventf(corev1.EventTypeWarning, "StandbyIgnored", "spec.standby can only be set on creation, ignored")
+		}
+
+		return
+	}
+
+	var section map[string]any
+	if p.Spec.Standby != nil {
+		if section, err = Section(p); err != nil {
+			return
+		}
+	}
+
+	err = configmap.UpdateConfig(ctx, p, func(config map[string]any) {
+		if section == nil {
+			delete(config, standbyClusterKey)
+		} else {
+			config[standbyClusterKey] = section
+		}
+	})
+	if err != nil {
+		return
+	}
+
+	if p.Spec.Standby == nil {
+		ctx.Eventf(corev1.EventTypeNormal, "Promoted", "Standby cluster promoted")
+
+		p.Status.Standby = false
+	}
+
+	return
+}
+
+// Section returns Patroni's standby_cluster section configured in spec.standby
+func Section(p *v1alpha1.PatroniPostgres) (section map[string]any, err error) {
+	spec := p.Spec.Standby
+	if spec.Host == "" && spec.Archive == nil {
+		return nil, errNoSource
+	}
+
+	cluster := standbyCluster{
+		PrimarySlotName: spec.PrimarySlotName,
+	}
+
+	if spec.Host != "" {
+		cluster.Host = spec.Host
+		cluster.Port = spec.GetPort()
+		cluster.CreateReplicaMethods = []string{basebackupMethod}
+	}
+
+	if spec.Archive != nil {
+		cluster.RestoreCommand = backup.StandbyRepository(p).RestoreCommand()
+	}
+
+	// values as decoded from Patroni's configuration, thus unchanged sections compare equal
+	data, err := json.Marshal(cluster)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &section)
+
+	return
+}
+
+// InitialConfig returns Patroni dynamic configuration of a new standby cluster, which must be in
+// place before members start, otherwise the first member would be promoted
+func InitialConfig(p *v1alpha1.PatroniPostgres) (config map[string]any, err error) {
+	section, err := Section(p)
+	if err != nil {
+		return
+	}
+
+	return map[string]any{
+		// as set by PATRONI_INITIAL_SYNCHRONOUS_MODE, it takes effect once promoted
+		synchronousModeKey: true,
+		standbyClusterKey:  section,
+	}, nil
+}
@@ -39,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/backup"
 	"github.com/k-web-s/patroni-postgres-operator/private/context"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/pvc"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/rbac"
@@ -52,9 +53,10 @@ const (
 	patroniPortName = "patroni"
 
 	PatroniSuperuserUsername   = "postgres"
-	patroniReplicationUsername = "standby"
+	PatroniReplicationUsername = "standby"
 
 	DataVolumeMountPath = "/var/lib/postgresql"
+	DataDirectory       = DataVolumeMountPath + "/data"
 
 	pvcManagementAnnotation      = "patronipostgres.kwebs.cloud/pvc-management"
 	pvcManagementAnnotationValue = "by-operator"
@@ -153,6 +155,10 @@ func ReconcileSts(ctx context.Context, p *v1alpha1.PatroniPostgres, patches ...P
 	}
 
 	sts.Spec.MinReadySeconds = 60
+	// pods are restarted by the operator, see rollingUpdate()
+	sts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.OnDeleteStatefulSetStrategyType,
+	}
 	sts.Spec.Replicas = &replicas
 	sts.Spec.Template = corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
@@ -221,7 +227,7 @@ func ReconcileSts(ctx context.Context, p *v1alpha1.PatroniPostgres, patches ...P
 						},
 						{
 							Name:  "PATRONI_REPLICATION_USERNAME",
-							Value: patroniReplicationUsername,
+							Value: PatroniReplicationUsername,
 						},
 						{
 							Name: "PATRONI_REPLICATION_PASSWORD",
@@ -304,6 +310,30 @@ func ReconcileSts(ctx context.Context, p *v1alpha1.PatroniPostgres, patches ...P
 
 	sts.Spec.Template.Spec.Containers[0].Env = append(sts.Spec.Template.Spec.Containers[0].Env, genNodeTagsEnvs(p)...)
 
+	mountCertificate(p, &sts.Spec.Template.Spec)
+
+	// archiving is configured first, thus archive_mode takes effect on rolling restart
+	var repository *backup.Repository
+	if p.Spec.Backup != nil && backup.Active(p) {
+		repository = backup.ClusterRepository(p)
+	} else if p.Status.Standby && p.Spec.Standby != nil && p.Spec.Standby.Archive != nil {
+		// standby_cluster's restore_command fetches from the source's archive
+		repository = backup.StandbyRepository(p)
+	}
+
+	if repository != nil {
+		var env []corev1.EnvVar
+		if env, err = repository.Env(DataDirectory); err != nil {
+			return
+		}
+
+		sts.Spec.Template.Spec.Containers[0].Env = append(sts.Spec.Template.Spec.Containers[0].Env, env...)
+	}
+
+	if p.Spec.Monitoring != nil {
+		sts.Spec.Template.Spec.Containers = append(sts.Spec.Template.Spec.Containers, exporterContainer(p))
+	}
+
 	sts.Spec.Template.Spec.Containers = append(sts.Spec.Template.Spec.Containers, p.Spec.ExtraContainers...)
 
 	if create {
@@ -323,9 +353,21 @@ func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
 		return
 	}
 
-	if int(sts.Status.ReadyReplicas) == len(p.Spec.Nodes) {
+	updating, err := rollingUpdate(ctx, p, sts)
+	if err != nil {
+		return
+	}
+
+	// no pod is outdated once the update revision is known and rolling update is done
+	if p.Status.TLS != nil && !updating && sts.Status.ObservedGeneration >= sts.Generation && sts.Status.UpdateRevision != "" {
+		p.Status.TLS.Mounted = true
+	}
+
+	if updating {
+		p.Status.State = v1alpha1.PatroniPostgresStateUpdating
+	} else if int(sts.Status.ReadyReplicas) == len(p.Spec.Nodes) {
 		p.Status.State = v1alpha1.PatroniPostgresStateReady
-		p.Status.UpgradeVersions = ctx.Image().UpgradeVersions(p.Status.Version)
+		p.Status.UpgradeVersions = ctx.Catalog().UpgradeVersions(p.Status.Version)
 	} else {
 		p.Status.State = v1alpha1.PatroniPostgresStateScaling
 	}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package statefulset
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/certificate"
+)
+
+// mountCertificate mounts the server certificate into postgres container. Once ssl is turned on,
+// Patroni's replication and superuser connections verify the server against the CA.
+func mountCertificate(p *v1alpha1.PatroniPostgres, spec *corev1.PodSpec) {
+	if p.Status.TLS == nil {
+		return
+	}
+
+	container := &spec.Containers[0]
+
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: certificate.VolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: p.Status.TLS.SecretName,
+				// private key must not be accessible by others
+				DefaultMode: ptr.To[int32](0640),
+			},
+		},
+	})
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      certificate.VolumeName,
+		MountPath: certificate.MountPath,
+		ReadOnly:  true,
+	})
+
+	if !certificate.Active(p) {
+		return
+	}
+
+	// members connect to each other by IP address, thus hostnames are not verified
+	for _, user := range []string{"REPLICATION", "SUPERUSER"} {
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name:  "PATRONI_" + user + "_SSLMODE",
+				Value: "verify-ca",
+			},
+			corev1.EnvVar{
+				Name:  "PATRONI_" + user + "_SSLROOTCERT",
+				Value: path.Join(certificate.MountPath, certificate.CAKey),
+			},
+		)
+	}
+}
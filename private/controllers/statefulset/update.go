@@ -0,0 +1,147 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package statefulset
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/patroni"
+)
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list;delete
+
+// rollingUpdate restarts pods not running the StatefulSet's update revision, one at a time.
+// Replicas are restarted first, the leader is switched over and restarted last.
+// Returns whether there are pods to be updated.
+func rollingUpdate(ctx context.Context, p *v1alpha1.PatroniPostgres, sts *appsv1.StatefulSet) (updating bool, err error) {
+	// wait for the StatefulSet controller to compute the update revision
+	if sts.Status.ObservedGeneration < sts.Generation || sts.Status.UpdateRevision == "" {
+		updating = p.Status.State == v1alpha1.PatroniPostgresStateUpdating
+		return
+	}
+
+	pods, err := patroni.Pods(ctx, p)
+	if err != nil {
+		return
+	}
+
+	var outdated []*corev1.Pod
+	for idx := range pods {
+		pod := &pods[idx]
+
+		if pod.Labels[appsv1.ControllerRevisionHashLabelKey] != sts.Status.UpdateRevision {
+			outdated = append(outdated, pod)
+		}
+	}
+
+	if len(outdated) == 0 {
+		return
+	}
+
+	updating = true
+
+	for _, pod := range pods {
+		// a restart is in progress
+		if pod.DeletionTimestamp != nil {
+			return
+		}
+	}
+
+	// not ready replicas are not serving, restart them without waiting
+	for _, pod := range outdated {
+		if pod.Name != p.Status.Leader && !podReady(pod) {
+			return true, restartPod(ctx, pod)
+		}
+	}
+
+	if !healthy(p, pods) {
+		return
+	}
+
+	for _, pod := range outdated {
+		if pod.Name != p.Status.Leader {
+			return true, restartPod(ctx, pod)
+		}
+	}
+
+	// only the leader remains
+	leader := outdated[0]
+
+	if len(pods) == 1 {
+		return true, restartPod(ctx, leader)
+	}
+
+	ctx.Eventf(corev1.EventTypeNormal, "UpdateSwitchover", "Switching over from %s before restarting it", leader.Name)
+
+	// let Patroni choose the candidate. The old leader is restarted as a replica afterwards.
+	_, err = patroni.Switchover(ctx, leader, leader.Name, "", nil)
+
+	return
+}
+
+// healthy returns whether all members are ready, and all replicas are replicating
+func healthy(p *v1alpha1.PatroniPostgres, pods []corev1.Pod) bool {
+	if p.Status.Leader == "" || len(pods) != len(p.Spec.Nodes) || len(p.Status.Members) != len(pods) {
+		return false
+	}
+
+	for idx := range pods {
+		if !podReady(&pods[idx]) {
+			return false
+		}
+	}
+
+	for _, member := range p.Status.Members {
+		if member.Name == p.Status.Leader {
+			continue
+		}
+
+		if member.State != patroni.StateStreaming && member.State != patroni.StateRunning {
+			return false
+		}
+	}
+
+	return true
+}
+
+func restartPod(ctx context.Context, pod *corev1.Pod) error {
+	ctx.Eventf(corev1.EventTypeNormal, "UpdateMember", "Restarting %s to apply updated pod template", pod.Name)
+
+	return ctx.Delete(ctx, pod)
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package switchover
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/patroni"
+)
+
+const (
+	// scheduledTimeout is the time after a scheduled switchover's time until
+	// it is considered as failed if the candidate has not become the leader
+	scheduledTimeout = 5 * time.Minute
+)
+
+// Reconcile performs switchovers requested in spec
+func Reconcile(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if p.Status.State != v1alpha1.PatroniPostgresStateReady {
+		return
+	}
+
+	spec := p.Spec.Switchover
+	status := p.Status.Switchover
+
+	if spec == nil {
+		if status != nil && status.Phase == v1alpha1.SwitchoverPhaseScheduled {
+			return cancel(ctx, p)
+		}
+
+		return
+	}
+
+	if status == nil || status.Candidate != spec.Candidate || !timeEqual(status.ScheduledAt, spec.ScheduledAt) {
+		return request(ctx, p)
+	}
+
+	if status.Phase == v1alpha1.SwitchoverPhaseScheduled {
+		checkScheduled(ctx, p)
+	}
+
+	return
+}
+
+// request initiates the switchover requested in spec
+func request(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	spec := p.Spec.Switchover
+	previous := p.Status.Switchover
+
+	p.Status.Switchover = &v1alpha1.SwitchoverStatus{
+		Candidate:   spec.Candidate,
+		ScheduledAt: spec.ScheduledAt,
+	}
+
+	if spec.Candidate >= len(p.Spec.Nodes) {
+		setPhase(ctx, p, v1alpha1.SwitchoverPhaseFailed, fmt.Sprintf("candidate %d does not exist", spec.Candidate))
+
+		return
+	}
+
+	candidate := memberName(p, spec.Candidate)
+
+	if p.Status.Leader == candidate {
+		setPhase(ctx, p, v1alpha1.SwitchoverPhaseSucceeded, fmt.Sprintf("%s is already the leader", candidate))
+
+		return
+	}
+
+	leaderPod, err := leaderPod(ctx, p)
+	if err != nil {
+		// retry later
+		p.Status.Switchover = previous
+
+		return
+	}
+
+	var scheduledAt *time.Time
+	if spec.ScheduledAt != nil && spec.ScheduledAt.After(time.Now()) {
+		scheduledAt = &spec.ScheduledAt.Time
+	}
+
+	scheduled, err := patroni.Switchover(ctx, leaderPod, p.Status.Leader, candidate, scheduledAt)
+	if err != nil {
+		setPhase(ctx, p, v1alpha1.SwitchoverPhaseFailed, err.Error())
+
+		return nil
+	}
+
+	if scheduled {
+		setPhase(ctx, p, v1alpha1.SwitchoverPhaseScheduled, fmt.Sprintf("switchover from %s to %s scheduled at %s", p.Status.Leader, candidate, scheduledAt.Format(time.RFC3339)))
+	} else {
+		setPhase(ctx, p, v1alpha1.SwitchoverPhaseSucceeded, fmt.Sprintf("switched over from %s to %s", p.Status.Leader, candidate))
+	}
+
+	return
+}
+
+// checkScheduled follows a scheduled switchover
+func checkScheduled(ctx context.Context, p *v1alpha1.PatroniPostgres) {
+	status := p.Status.Switchover
+	candidate := memberName(p, status.Candidate)
+
+	if p.Status.Leader == candidate {
+		setPhase(ctx, p, v1alpha1.SwitchoverPhaseSucceeded, fmt.Sprintf("%s became the leader", candidate))
+
+		return
+	}
+
+	if status.ScheduledAt != nil && time.Since(status.ScheduledAt.Time) > scheduledTimeout {
+		setPhase(ctx, p, v1alpha1.SwitchoverPhaseFailed, fmt.Sprintf("%s did not become the leader in time", candidate))
+	}
+}
+
+// cancel cancels a scheduled switchover
+func cancel(ctx context.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	leaderPod, err := leaderPod(ctx, p)
+	if err != nil {
+		return
+	}
+
+	if err = patroni.CancelSwitchover(ctx, leaderPod); err != nil {
+		return
+	}
+
+	setPhase(ctx, p, v1alpha1.SwitchoverPhaseCancelled, "switchover removed from spec")
+
+	return
+}
+
+func setPhase(ctx context.Context, p *v1alpha1.PatroniPostgres, phase v1alpha1.SwitchoverPhase, message string) {
+	p.Status.Switchover.Phase = phase
+	p.Status.Switchover.Message = message
+	p.Status.Switchover.LastTransitionTime = metav1.Now()
+
+	eventtype := corev1.EventTypeNormal
+	if phase == v1alpha1.SwitchoverPhaseFailed {
+		eventtype = corev1.EventTypeWarning
+	}
+
+	ctx.Event(eventtype, "Switchover"+string(phase), message)
+}
+
+func leaderPod(ctx context.Context, p *v1alpha1.PatroniPostgres) (pod *corev1.Pod, err error) {
+	if p.Status.Leader == "" {
+		return nil, fmt.Errorf("leader is unknown")
+	}
+
+	pods, err := patroni.Pods(ctx, p)
+	if err != nil {
+		return
+	}
+
+	for idx := range pods {
+		if pods[idx].Name == p.Status.Leader {
+			return &pods[idx], nil
+		}
+	}
+
+	return nil, fmt.Errorf("leader pod %s not found", p.Status.Leader)
+}
+
+func memberName(p *v1alpha1.PatroniPostgres, idx int) string {
+	return fmt.Sprintf("%s-%d", p.Name, idx)
+}
+
+func timeEqual(a, b *metav1.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(b)
+}
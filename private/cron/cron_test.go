@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1-a * * * *",
+		"*/x * * * *",
+
+		// never matching
+		"0 0 30 2 *",
+		"0 0 31 2 *",
+		"0 0 31 4,6,9,11 *",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded, expected an error", spec)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	for _, tc := range []struct {
+		spec string
+		from string
+		next string
+	}{
+		// daily
+		{"0 2 * * *", "2026-10-17 01:59", "2026-10-17 02:00"},
+		{"0 2 * * *", "2026-10-17 02:00", "2026-10-18 02:00"},
+		{"0 2 * * *", "2026-12-31 03:00", "2027-01-01 02:00"},
+
+		// steps and ranges
+		{"*/15 * * * *", "2026-10-17 10:07", "2026-10-17 10:15"},
+		{"*/15 * * * *", "2026-10-17 10:45", "2026-10-17 11:00"},
+		{"10-20/5 * * * *", "2026-10-17 10:16", "2026-10-17 10:20"},
+		{"10-20/5 * * * *", "2026-10-17 10:20", "2026-10-17 11:10"},
+		{"30/10 * * * *", "2026-10-17 10:55", "2026-10-17 11:30"},
+		{"0 9-17 * * *", "2026-10-17 17:30", "2026-10-18 09:00"},
+
+		// lists
+		{"0 6,18 * * *", "2026-10-17 06:00", "2026-10-17 18:00"},
+		{"0 0 1,15 * *", "2026-10-02 00:00", "2026-10-15 00:00"},
+
+		// day of week, 2026-10-17 is a Saturday
+		{"0 3 * * 0", "2026-10-17 12:00", "2026-10-18 03:00"},
+		{"0 3 * * 7", "2026-10-17 12:00", "2026-10-18 03:00"},
+		{"0 3 * * 1-5", "2026-10-17 12:00", "2026-10-19 03:00"},
+
+		// day of month or day of week when both are restricted
+		{"0 0 20 * 1", "2026-10-17 12:00", "2026-10-19 00:00"},
+		{"0 0 19 * 3", "2026-10-17 12:00", "2026-10-19 00:00"},
+		{"0 0 20 * 1", "2026-10-19 12:00", "2026-10-20 00:00"},
+
+		// when a day field starts with *, both have to match
+		{"0 0 */2 * 1", "2026-10-17 12:00", "2026-10-19 00:00"},
+		{"0 0 1 * */7", "2026-10-17 12:00", "2026-11-01 00:00"},
+
+		// months
+		{"0 0 1 1 *", "2026-10-17 12:00", "2027-01-01 00:00"},
+		{"0 0 31 * *", "2026-11-01 00:00", "2026-12-31 00:00"},
+
+		// February 29
+		{"0 0 29 2 *", "2026-10-17 12:00", "2028-02-29 00:00"},
+		{"0 0 29 2 *", "2028-02-29 00:00", "2032-02-29 00:00"},
+
+		// February 1 or 29 on a Monday, 11 years later
+		{"0 0 */28 2 1", "2027-02-02 00:00", "2038-02-01 00:00"},
+
+		// seconds are truncated
+		{"* * * * *", "2026-10-17 10:07:30", "2026-10-17 10:08"},
+	} {
+		s, err := Parse(tc.spec)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.spec, err)
+			continue
+		}
+
+		from := parseTime(t, tc.from)
+		if next := s.Next(from); !next.Equal(parseTime(t, tc.next)) {
+			t.Errorf("%q.Next(%s) = %s, expected %s", tc.spec, tc.from, next.Format(time.DateTime), tc.next)
+		}
+	}
+}
+
+func parseTime(t *testing.T, s string) time.Time {
+	t.Helper()
+
+	for _, layout := range []string{"2006-01-02 15:04", time.DateTime} {
+		if tm, err := time.Parse(layout, s); err == nil {
+			return tm
+		}
+	}
+
+	t.Fatalf("invalid time %q", s)
+
+	return time.Time{}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package image
+
+import (
+	gocontext "context"
+	"flag"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	"github.com/k-web-s/patroni-postgres-operator/private/operator"
+)
+
+const (
+	// CatalogKey holds image catalog entries in catalog ConfigMap
+	CatalogKey = "images.yaml"
+)
+
+var (
+	catalogName = flag.String("image-catalog", "kwebs-patroni-postgres-image-catalog", "Name of ConfigMap in operator's namespace holding the image catalog")
+
+	digestRe = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+	// lastCatalog is the last valid catalog loaded
+	lastCatalog atomic.Pointer[Catalog]
+)
+
+// catalogEntry is an image in the catalog ConfigMap
+type catalogEntry struct {
+	// Image reference
+	Image string `json:"image"`
+
+	// Digest optionally pins the image
+	Digest string `json:"digest,omitempty"`
+
+	// Versions lists PostgreSQL major versions shipped in the image
+	Versions []int `json:"versions"`
+}
+
+// CatalogEnabled returns whether the image catalog ConfigMap is to be looked up
+func CatalogEnabled() bool {
+	return *catalogName != "" && operator.Namespace() != ""
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// LoadCatalog reads the image catalog ConfigMap. If it does not exist, the builtin images are used.
+// If it is invalid, the last valid catalog is used, thus clusters are not blocked by a bad edit.
+func LoadCatalog(ctx gocontext.Context, cl client.Reader) (*Catalog, error) {
+	if !CatalogEnabled() {
+		return newCatalog(images), nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: operator.Namespace(), Name: *catalogName}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return storeCatalog(newCatalog(images)), nil
+		}
+
+		return nil, err
+	}
+
+	catalog, err := parseCatalog(cm.Data[CatalogKey])
+	if err != nil {
+		last := lastCatalog.Load()
+		if last == nil {
+			return nil, err
+		}
+
+		log.FromContext(ctx).Error(err, "using last valid image catalog")
+
+		return last, nil
+	}
+
+	return storeCatalog(catalog), nil
+}
+
+// storeCatalog records c as the last valid catalog
+func storeCatalog(c *Catalog) *Catalog {
+	lastCatalog.Store(c)
+
+	return c
+}
+
+// parseCatalog parses catalog entries
+func parseCatalog(data string) (*Catalog, error) {
+	var entries []catalogEntry
+	if err := yaml.UnmarshalStrict([]byte(data), &entries); err != nil {
+		return nil, fmt.Errorf("image catalog: %w", err)
+	}
+
+	catalogImages := make([]*image, 0, len(entries))
+	for idx, entry := range entries {
+		if entry.Image == "" {
+			return nil, fmt.Errorf("image catalog: entry %d: image is empty", idx)
+		}
+
+		if len(entry.Versions) == 0 {
+			return nil, fmt.Errorf("image catalog: entry %d: no versions", idx)
+		}
+
+		if entry.Digest != "" && !digestRe.MatchString(entry.Digest) {
+			return nil, fmt.Errorf("image catalog: entry %d: invalid digest %q", idx, entry.Digest)
+		}
+
+		catalogImages = append(catalogImages, &image{
+			image:    entry.Image,
+			digest:   entry.Digest,
+			versions: entry.Versions,
+		})
+	}
+
+	return newCatalog(catalogImages), nil
+}
+
+// IsCatalog returns whether obj is the image catalog ConfigMap
+func IsCatalog(obj client.Object) bool {
+	return CatalogEnabled() && obj.GetNamespace() == operator.Namespace() && obj.GetName() == *catalogName
+}
+
+// CacheByObject restricts cached ConfigMaps to the image catalog
+func CacheByObject() map[client.Object]cache.ByObject {
+	if !CatalogEnabled() {
+		return nil
+	}
+
+	return map[client.Object]cache.ByObject{
+		&corev1.ConfigMap{}: {
+			Namespaces: map[string]cache.Config{
+				operator.Namespace(): {},
+			},
+			Field: fields.OneTermEqualSelector("metadata.name", *catalogName),
+		},
+	}
+}
@@ -32,10 +32,15 @@ type Image interface {
 
 type image struct {
 	image    string
+	digest   string
 	versions []int
 }
 
 func (i image) Image() string {
+	if i.digest != "" {
+		return i.image + "@" + i.digest
+	}
+
 	return i.image
 }
 
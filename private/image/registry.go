@@ -25,9 +25,13 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 
 package image
 
+import (
+	"slices"
+)
+
 var (
-	registry = map[int]Image{}
-	images   = []*image{
+	// builtin images, used when no catalog is configured
+	images = []*image{
 		{
 			image:    "ghcr.io/rkojedzinszky/postgres-patroni:20251017",
 			versions: []int{13, 15},
@@ -39,14 +43,83 @@ var (
 	}
 )
 
-func init() {
+// Catalog maps PostgreSQL major versions to images
+type Catalog struct {
+	registry map[int]Image
+}
+
+// newCatalog builds a catalog, for versions contained in multiple images, later entries win
+func newCatalog(images []*image) *Catalog {
+	c := &Catalog{
+		registry: map[int]Image{},
+	}
+
 	for _, img := range images {
 		for _, v := range img.versions {
-			registry[v] = img
+			c.registry[v] = img
 		}
 	}
+
+	return c
 }
 
-func GetImage(version int) Image {
-	return registry[version]
+// GetImage returns image to be used for version, nil if version is not supported
+func (c *Catalog) GetImage(version int) Image {
+	return c.registry[version]
+}
+
+// UpgradeVersions returns versions reachable from version, possibly through multiple upgrades
+func (c *Catalog) UpgradeVersions(version int) (versions []int) {
+	for v := range c.paths(version) {
+		versions = append(versions, v)
+	}
+
+	slices.Sort(versions)
+
+	return
+}
+
+// UpgradePath returns versions to upgrade through to reach to from version from, including
+// the target version. Each upgrade is performed with the image of the version being upgraded.
+// Returns nil if to is not reachable.
+func (c *Catalog) UpgradePath(from, to int) []int {
+	return c.paths(from)[to]
+}
+
+// paths returns shortest upgrade paths to all versions reachable from version
+func (c *Catalog) paths(version int) map[int][]int {
+	paths := map[int][]int{}
+
+	queue := []int{version}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		img := c.registry[current]
+		if img == nil {
+			continue
+		}
+
+		for _, next := range img.UpgradeVersions(current) {
+			if _, ok := paths[next]; ok {
+				continue
+			}
+
+			paths[next] = append(slices.Clone(paths[current]), next)
+			queue = append(queue, next)
+		}
+	}
+
+	return paths
+}
+
+// Versions returns supported versions in ascending order
+func (c *Catalog) Versions() (versions []int) {
+	for v := range c.registry {
+		versions = append(versions, v)
+	}
+
+	slices.Sort(versions)
+
+	return
 }
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package jobresult
+
+import (
+	"encoding/json"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	upgradecommon "github.com/k-web-s/patroni-postgres-operator/private/upgrade/common"
+)
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+
+// Get parses result of job, which is written as a versioned document to the
+// termination message of its succeeded container
+func Get(ctx context.Context, job *batchv1.Job, result any) (err error) {
+	var ls labels.Selector
+	if ls, err = metav1.LabelSelectorAsSelector(job.Spec.Selector); err != nil {
+		return
+	}
+
+	var pods corev1.PodList
+	if err = ctx.List(ctx, &pods, &client.ListOptions{Namespace: job.Namespace, LabelSelector: ls}); err != nil {
+		return
+	}
+
+	for idx := range pods.Items {
+		pod := &pods.Items[idx]
+
+		if pod.Status.Phase != corev1.PodSucceeded || len(pod.Status.ContainerStatuses) == 0 {
+			continue
+		}
+
+		terminated := pod.Status.ContainerStatuses[0].State.Terminated
+		if terminated == nil {
+			continue
+		}
+
+		var doc upgradecommon.Result
+		if err = json.Unmarshal([]byte(terminated.Message), &doc); err != nil {
+			return fmt.Errorf("job %s: parsing result: %w", job.Name, err)
+		}
+
+		if doc.Version != upgradecommon.ResultVersion {
+			return fmt.Errorf("job %s: unsupported result version %d", job.Name, doc.Version)
+		}
+
+		return json.Unmarshal(doc.Result, result)
+	}
+
+	return fmt.Errorf("no succeeded pod found for job %s", job.Name)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+)
+
+const (
+	namespace = "patronipostgres"
+)
+
+var (
+	clusterLabels = []string{"namespace", "name"}
+
+	state = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "state",
+		Help:      "Cluster state, 1 for the current state",
+	}, append(clusterLabels, "state"))
+
+	membersReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "members_ready",
+		Help:      "Number of ready members",
+	}, clusterLabels)
+
+	membersDesired = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "members_desired",
+		Help:      "Number of desired members",
+	}, clusterLabels)
+
+	version = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "version",
+		Help:      "Current PostgreSQL major version",
+	}, clusterLabels)
+
+	targetVersion = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "target_version",
+		Help:      "Requested PostgreSQL major version",
+	}, clusterLabels)
+
+	upgradeStepStart = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "upgrade_step_start_time_seconds",
+		Help:      "Start time of the upgrade step in progress, since unix epoch",
+	}, append(clusterLabels, "step"))
+
+	upgradeStepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upgrade_step_duration_seconds",
+		Help:      "Time spent in finished upgrade steps",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, append(clusterLabels, "step"))
+
+	upgradeJobFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upgrade_job_failures_total",
+		Help:      "Number of failed upgrade jobs",
+	}, append(clusterLabels, "step"))
+
+	volumeCapacity = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "volume_capacity_bytes",
+		Help:      "Capacity of member volumes",
+	}, append(clusterLabels, "claim"))
+
+	volumeRequested = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "volume_requested_bytes",
+		Help:      "Requested size of member volumes",
+	}, append(clusterLabels, "claim"))
+
+	// perCluster lists collectors reset on each update
+	perCluster = []interface {
+		DeletePartialMatch(prometheus.Labels) int
+	}{
+		state,
+		membersReady,
+		membersDesired,
+		version,
+		targetVersion,
+		upgradeStepStart,
+		volumeCapacity,
+		volumeRequested,
+	}
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		state,
+		membersReady,
+		membersDesired,
+		version,
+		targetVersion,
+		upgradeStepStart,
+		upgradeStepDuration,
+		upgradeJobFailures,
+		volumeCapacity,
+		volumeRequested,
+	)
+}
+
+// Update sets cluster metrics from p's status
+func Update(p *v1alpha1.PatroniPostgres) {
+	reset(p.Namespace, p.Name)
+
+	state.WithLabelValues(p.Namespace, p.Name, string(p.Status.State)).Set(1)
+	membersReady.WithLabelValues(p.Namespace, p.Name).Set(float64(p.Status.Ready))
+	membersDesired.WithLabelValues(p.Namespace, p.Name).Set(float64(len(p.Spec.Nodes)))
+	version.WithLabelValues(p.Namespace, p.Name).Set(float64(p.Status.Version))
+	targetVersion.WithLabelValues(p.Namespace, p.Name).Set(float64(p.Spec.Version))
+
+	if p.Status.UpgradeStepStartTime != nil {
+		upgradeStepStart.WithLabelValues(p.Namespace, p.Name, string(p.Status.State)).Set(float64(p.Status.UpgradeStepStartTime.Unix()))
+	}
+
+	for _, volume := range p.Status.VolumeStatuses {
+		volumeCapacity.WithLabelValues(p.Namespace, p.Name, volume.ClaimName).Set(volume.Capacity.AsApproximateFloat64())
+		volumeRequested.WithLabelValues(p.Namespace, p.Name, volume.ClaimName).Set(p.Spec.VolumeSize.AsApproximateFloat64())
+	}
+}
+
+// Delete removes all metrics of a cluster
+func Delete(ns, name string) {
+	reset(ns, name)
+
+	labels := prometheus.Labels{"namespace": ns, "name": name}
+	upgradeStepDuration.DeletePartialMatch(labels)
+	upgradeJobFailures.DeletePartialMatch(labels)
+}
+
+// ObserveUpgradeStep records duration of a finished upgrade step
+func ObserveUpgradeStep(p *v1alpha1.PatroniPostgres, step v1alpha1.PatroniPostgresState, seconds float64) {
+	upgradeStepDuration.WithLabelValues(p.Namespace, p.Name, string(step)).Observe(seconds)
+}
+
+// UpgradeJobFailed counts a failed upgrade job
+func UpgradeJobFailed(p *v1alpha1.PatroniPostgres, step v1alpha1.PatroniPostgresState) {
+	upgradeJobFailures.WithLabelValues(p.Namespace, p.Name, string(step)).Inc()
+}
+
+// reset removes gauges of a cluster, thus stale label values disappear
+func reset(ns, name string) {
+	labels := prometheus.Labels{"namespace": ns, "name": name}
+	for _, c := range perCluster {
+		c.DeletePartialMatch(labels)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package operator
+
+import (
+	"flag"
+	"os"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+
+	podLabel      = "control-plane"
+	podLabelValue = "kwebs-patroni-postgres-operator"
+)
+
+var (
+	namespace = flag.String("operator-namespace", os.Getenv("POD_NAMESPACE"), "Namespace the operator is running in")
+)
+
+// Namespace returns the namespace the operator is running in
+func Namespace() string {
+	return *namespace
+}
+
+// NetworkPolicyPeer returns a peer matching operator PODs. When operator's
+// namespace is unknown, operator PODs are matched in all namespaces.
+func NetworkPolicyPeer() networking.NetworkPolicyPeer {
+	namespaceSelector := &metav1.LabelSelector{}
+	if *namespace != "" {
+		namespaceSelector.MatchLabels = map[string]string{
+			namespaceNameLabel: *namespace,
+		}
+	}
+
+	return networking.NetworkPolicyPeer{
+		NamespaceSelector: namespaceSelector,
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				podLabel: podLabelValue,
+			},
+		},
+	}
+}
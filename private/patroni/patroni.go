@@ -0,0 +1,267 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package patroni
+
+import (
+	"bytes"
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+)
+
+const (
+	// APIPort is Patroni's REST API port
+	APIPort = 8008
+
+	// Member roles
+	RoleLeader        = "leader"
+	RoleStandbyLeader = "standby_leader"
+	RoleSyncStandby   = "sync_standby"
+	RoleReplica       = "replica"
+
+	// Member states
+	StateRunning   = "running"
+	StateStreaming = "streaming"
+
+	requestTimeout = 2 * time.Second
+
+	// switchoverTimeout is used for switchover requests, which wait for
+	// the new leader to be elected
+	switchoverTimeout = 90 * time.Second
+)
+
+// PendingRestartReason describes a parameter change requiring restart
+type PendingRestartReason struct {
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// MemberStatus is returned by a member's /patroni endpoint
+type MemberStatus struct {
+	State                string                          `json:"state"`
+	Role                 string                          `json:"role"`
+	PendingRestart       bool                            `json:"pending_restart"`
+	PendingRestartReason map[string]PendingRestartReason `json:"pending_restart_reason"`
+}
+
+// ClusterMember describes a member as returned by the /cluster endpoint
+type ClusterMember struct {
+	Name           string          `json:"name"`
+	Role           string          `json:"role"`
+	State          string          `json:"state"`
+	Host           string          `json:"host"`
+	Timeline       int64           `json:"timeline"`
+	Lag            json.RawMessage `json:"lag,omitempty"`
+	PendingRestart bool            `json:"pending_restart"`
+}
+
+// LagBytes returns replication lag in bytes, and whether it is known
+func (m *ClusterMember) LagBytes() (lag int64, ok bool) {
+	if len(m.Lag) == 0 {
+		// leader has no lag reported
+		return 0, m.Role == RoleLeader || m.Role == RoleStandbyLeader
+	}
+
+	ok = json.Unmarshal(m.Lag, &lag) == nil
+
+	return
+}
+
+// Cluster is returned by the /cluster endpoint
+type Cluster struct {
+	Members []ClusterMember `json:"members"`
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+
+// Pods returns cluster's postgres PODs
+func Pods(ctx context.Context, p *v1alpha1.PatroniPostgres) (pods []corev1.Pod, err error) {
+	var podList corev1.PodList
+
+	if err = ctx.List(ctx, &podList, &client.ListOptions{
+		Namespace:     p.Namespace,
+		LabelSelector: labels.SelectorFromSet(ctx.PodLabels(context.ComponentPostgres)),
+	}); err != nil {
+		return
+	}
+
+	pods = podList.Items
+
+	return
+}
+
+// GetMemberStatus queries a member's status through its /patroni endpoint
+func GetMemberStatus(ctx gocontext.Context, pod *corev1.Pod) (status *MemberStatus, err error) {
+	status = &MemberStatus{}
+	err = get(ctx, pod, "/patroni", status)
+
+	return
+}
+
+// GetCluster queries cluster status from the first responding POD
+func GetCluster(ctx gocontext.Context, pods []corev1.Pod) (cluster *Cluster, err error) {
+	err = fmt.Errorf("no pods to query")
+
+	for idx := range pods {
+		cluster = &Cluster{}
+		if err = get(ctx, &pods[idx], "/cluster", cluster); err == nil {
+			return
+		}
+	}
+
+	return nil, err
+}
+
+type switchoverRequest struct {
+	Leader      string     `json:"leader,omitempty"`
+	Candidate   string     `json:"candidate,omitempty"`
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+}
+
+// Switchover requests a switchover from leader to candidate through pod's API, optionally
+// scheduled. Returns whether the switchover has been scheduled instead of performed.
+func Switchover(ctx gocontext.Context, pod *corev1.Pod, leader, candidate string, scheduledAt *time.Time) (scheduled bool, err error) {
+	body, err := json.Marshal(switchoverRequest{
+		Leader:      leader,
+		Candidate:   candidate,
+		ScheduledAt: scheduledAt,
+	})
+	if err != nil {
+		return
+	}
+
+	status, err := do(ctx, pod, http.MethodPost, "/switchover", body, switchoverTimeout)
+
+	scheduled = status == http.StatusAccepted
+
+	return
+}
+
+// CancelSwitchover cancels a scheduled switchover
+func CancelSwitchover(ctx gocontext.Context, pod *corev1.Pod) (err error) {
+	status, err := do(ctx, pod, http.MethodDelete, "/switchover", nil, requestTimeout)
+	if status == http.StatusNotFound {
+		// no scheduled switchover
+		err = nil
+	}
+
+	return
+}
+
+// Reload reloads Patroni configuration, and PostgreSQL on pod, e.g. to pick up renewed certificates
+func Reload(ctx gocontext.Context, pod *corev1.Pod) (err error) {
+	_, err = do(ctx, pod, http.MethodPost, "/reload", nil, requestTimeout)
+
+	return
+}
+
+// do sends a request to pod's API. Returns error containing response body for
+// non-successful responses.
+func do(ctx gocontext.Context, pod *corev1.Pod, method, path string, body []byte, timeout time.Duration) (status int, err error) {
+	url, err := apiURL(pod, path)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := gocontext.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	status = resp.StatusCode
+
+	message, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		err = fmt.Errorf("%s %s: %d: %s", method, path, status, strings.TrimSpace(string(message)))
+	}
+
+	return
+}
+
+func apiURL(pod *corev1.Pod, path string) (string, error) {
+	if pod.Status.PodIP == "" {
+		return "", fmt.Errorf("pod %s has no IP address", pod.Name)
+	}
+
+	return fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(APIPort)), path), nil
+}
+
+func get(ctx gocontext.Context, pod *corev1.Pod, path string, v any) (err error) {
+	url, err := apiURL(pod, path)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := gocontext.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	// Patroni returns 503 on some endpoints for replicas, with valid content
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return fmt.Errorf("unexpected http status from %s: %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
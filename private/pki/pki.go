@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+const (
+	certificatePEMType = "CERTIFICATE"
+	privateKeyPEMType  = "PRIVATE KEY"
+
+	// backdate accounts for clock skew between the operator and clients
+	backdate = 5 * time.Minute
+)
+
+// KeyPair holds a PEM encoded certificate and its private key
+type KeyPair struct {
+	Certificate []byte
+	Key         []byte
+}
+
+// GenerateCA generates a self-signed certificate authority
+func GenerateCA(commonName string, validity time.Duration) (*KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	return generate(template, nil, validity)
+}
+
+// GenerateServerCertificate generates a server certificate for dnsNames signed by ca
+func GenerateServerCertificate(ca *KeyPair, commonName string, dnsNames []string, validity time.Duration) (*KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		DNSNames:    dnsNames,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	return generate(template, ca, validity)
+}
+
+// GenerateClientCertificate generates a client certificate for commonName signed by ca
+func GenerateClientCertificate(ca *KeyPair, commonName string, validity time.Duration) (*KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	return generate(template, ca, validity)
+}
+
+// ParseCertificate parses a PEM encoded certificate
+func ParseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != certificatePEMType {
+		return nil, fmt.Errorf("no certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// NeedsRenewal reports whether certificate is invalid, is not signed by ca, does not cover
+// dnsNames, or expires within renewBefore
+func NeedsRenewal(certPEM []byte, ca *KeyPair, dnsNames []string, renewBefore time.Duration) bool {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return true
+	}
+
+	if time.Now().Add(renewBefore).After(cert.NotAfter) {
+		return true
+	}
+
+	if ca != nil {
+		caCert, err := ParseCertificate(ca.Certificate)
+		if err != nil || cert.CheckSignatureFrom(caCert) != nil {
+			return true
+		}
+	}
+
+	for _, name := range dnsNames {
+		if !slices.Contains(cert.DNSNames, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generate creates a certificate from template, signed by ca, or self-signed if ca is nil
+func generate(template *x509.Certificate, ca *KeyPair, validity time.Duration) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template.SerialNumber = serial
+	template.NotBefore = now.Add(-backdate)
+	template.NotAfter = now.Add(validity)
+
+	parent := template
+	var signer any = key
+	if ca != nil {
+		if parent, err = ParseCertificate(ca.Certificate); err != nil {
+			return nil, err
+		}
+
+		if signer, err = parsePrivateKey(ca.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPair{
+		Certificate: pem.EncodeToMemory(&pem.Block{Type: certificatePEMType, Bytes: der}),
+		Key:         pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Bytes: keyDER}),
+	}, nil
+}
+
+func parsePrivateKey(keyPEM []byte) (any, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil || block.Type != privateKeyPEMType {
+		return nil, fmt.Errorf("no private key found")
+	}
+
+	return x509.ParsePKCS8PrivateKey(block.Bytes)
+}
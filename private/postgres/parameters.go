@@ -0,0 +1,119 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+)
+
+var (
+	// removedParameters maps parameters to the major version they were removed in
+	removedParameters = map[string]int{
+		"operator_precedence_warning":       14,
+		"vacuum_cleanup_index_scale_factor": 14,
+		"stats_temp_directory":              15,
+		"force_parallel_mode":               16,
+		"promote_trigger_file":              16,
+		"vacuum_defer_cleanup_age":          16,
+		"db_user_namespace":                 17,
+		"old_snapshot_threshold":            17,
+		"trace_recovery_messages":           17,
+	}
+
+	boolValues = []string{"on", "off", "true", "false", "yes", "no", "1", "0"}
+)
+
+// ValidateParameterForVersion checks whether a parameter exists in the given major version
+func ValidateParameterForVersion(name string, version int) error {
+	if removed, ok := removedParameters[name]; ok && version >= removed {
+		return fmt.Errorf("parameter %s has been removed in version %d", name, removed)
+	}
+
+	return nil
+}
+
+// ValidateParameter validates a parameter's value according to pg_settings of the connected server
+func ValidateParameter(ctx context.Context, conn *pgx.Conn, name, value string) (err error) {
+	var vartype string
+	var minVal, maxVal *string
+	var enumVals []string
+
+	err = conn.QueryRow(ctx, "SELECT vartype, min_val, max_val, enumvals FROM pg_catalog.pg_settings WHERE name = $1", name).
+		Scan(&vartype, &minVal, &maxVal, &enumVals)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// placeholder parameters of extensions may not be loaded
+		if strings.Contains(name, ".") {
+			return nil
+		}
+
+		return fmt.Errorf("unknown parameter %s", name)
+	}
+	if err != nil {
+		return
+	}
+
+	switch vartype {
+	case "bool":
+		if !slices.Contains(boolValues, strings.ToLower(value)) {
+			return fmt.Errorf("parameter %s: invalid boolean value %q", name, value)
+		}
+	case "enum":
+		if !slices.ContainsFunc(enumVals, func(v string) bool { return strings.EqualFold(v, value) }) {
+			return fmt.Errorf("parameter %s: invalid value %q, valid values are: %s", name, value, strings.Join(enumVals, ", "))
+		}
+	case "integer", "real":
+		// values with units are checked by the server
+		v, perr := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if perr != nil {
+			if value == "" || !(value[0] >= '0' && value[0] <= '9' || value[0] == '-') {
+				return fmt.Errorf("parameter %s: invalid numeric value %q", name, value)
+			}
+
+			return nil
+		}
+
+		if minVal != nil {
+			if limit, _ := strconv.ParseFloat(*minVal, 64); v < limit {
+				return fmt.Errorf("parameter %s: value %s is below minimum %s", name, value, *minVal)
+			}
+		}
+		if maxVal != nil {
+			if limit, _ := strconv.ParseFloat(*maxVal, 64); v > limit {
+				return fmt.Errorf("parameter %s: value %s is above maximum %s", name, value, *maxVal)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package postgres
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	"github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/certificate"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/service"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+)
+
+const (
+	connectTimeout = 5 * time.Second
+)
+
+// Connect connects to the primary as superuser, using database
+func Connect(ctx context.Context, p *v1alpha1.PatroniPostgres, database string) (conn *pgx.Conn, err error) {
+	password, err := secret.SuperuserPassword(ctx, p)
+	if err != nil {
+		return
+	}
+
+	config, err := pgx.ParseConfig("sslmode=disable")
+	if err != nil {
+		return
+	}
+
+	config.Host = fmt.Sprintf("%s.%s.svc", p.Name, p.Namespace)
+	config.Port = service.PostgresPort
+	config.User = statefulset.PatroniSuperuserUsername
+	config.Password = password
+	config.Database = database
+	config.ConnectTimeout = connectTimeout
+
+	if certificate.Active(p) {
+		// verify-full
+		config.TLSConfig = &tls.Config{
+			ServerName: config.Host,
+		}
+		if config.TLSConfig.RootCAs, err = certificate.RootCAs(ctx, p); err != nil {
+			return
+		}
+	}
+
+	return pgx.ConnectConfig(ctx, config)
+}
+
+// QuoteIdentifier quotes an identifier to be used in SQL statements
+func QuoteIdentifier(s string) string {
+	return pgx.Identifier{s}.Sanitize()
+}
+
+// QuoteLiteral quotes a string literal to be used in SQL statements
+func QuoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package upgrade
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/pvc"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/security"
+)
+
+var (
+	//go:embed upgrade-scripts/abort-restore
+	abortRestore string
+
+	errAbortRestoreJobFailed = fmt.Errorf("abort restore job failed")
+
+	// abortableStates lists states in which the old cluster is still intact
+	abortableStates = []v1alpha1.PatroniPostgresState{
+		v1alpha1.PatroniPostgresStateUpgradePreupgrade,
+		v1alpha1.PatroniPostgresStateUpgradePreflight,
+		v1alpha1.PatroniPostgresStateUpgradePreupgradeScaleDown,
+		v1alpha1.PatroniPostgresStateUpgradePreupgradeSync,
+		v1alpha1.PatroniPostgresStateUpgradeScaleDown,
+		v1alpha1.PatroniPostgresStateUpgradePrimary,
+	}
+)
+
+// abortRequested returns whether the upgrade is to be aborted
+func abortRequested(p *v1alpha1.PatroniPostgres) bool {
+	if _, ok := p.Annotations[v1alpha1.AbortUpgradeAnnotation]; ok {
+		return true
+	}
+
+	return p.Spec.UpgradeAbortAfterFailures > 0 && p.Status.UpgradeFailures >= p.Spec.UpgradeAbortAfterFailures
+}
+
+// abortable returns whether the upgrade can be aborted in current state
+func abortable(p *v1alpha1.PatroniPostgres) bool {
+	return slices.Contains(abortableStates, p.Status.State)
+}
+
+// aborting returns whether the upgrade is being aborted
+func aborting(p *v1alpha1.PatroniPostgres) bool {
+	return p.Status.State == v1alpha1.PatroniPostgresStateUpgradeAbortScaleDown || p.Status.State == v1alpha1.PatroniPostgresStateUpgradeAbortRestore
+}
+
+type abortScaledownHandler struct {
+	scaledownHandler
+}
+
+func (abortScaledownHandler) name() v1alpha1.PatroniPostgresState {
+	return v1alpha1.PatroniPostgresStateUpgradeAbortScaleDown
+}
+
+func (h abortScaledownHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (done bool, err error) {
+	// stop running upgrade jobs
+	var deleted bool
+	for _, jobname := range []string{
+		upgradeJobname(p, preupgradeJob{p}),
+		fmt.Sprintf("%s-preflight", p.Name),
+		upgradeJobname(p, preupgradeSyncJob{p}),
+		fmt.Sprintf("%s-up", p.Name),
+	} {
+		if deleted, err = deleteJob(ctx, p, jobname); err != nil || !deleted {
+			return
+		}
+	}
+
+	return h.scaledownHandler.handle(ctx, p)
+}
+
+// deleteJob deletes a job with its pods. Returns whether the job is gone.
+func deleteJob(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, jobname string) (deleted bool, err error) {
+	job := &batchv1.Job{}
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: jobname}, job)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+
+		return
+	}
+
+	if job.DeletionTimestamp == nil {
+		deletePropagationPolicy := metav1.DeletePropagationForeground
+
+		err = ctx.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &deletePropagationPolicy})
+	}
+
+	return
+}
+
+type abortRestoreHandler struct {
+}
+
+func (abortRestoreHandler) name() v1alpha1.PatroniPostgresState {
+	return v1alpha1.PatroniPostgresStateUpgradeAbortRestore
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;delete
+
+func (abortRestoreHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (done bool, err error) {
+	job := &batchv1.Job{}
+	jobname := fmt.Sprintf("%s-abort", p.Name)
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: jobname}, job)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return
+		}
+
+		var leaderIndex int
+		leaderIndex, err = configmap.GetSyncLeader(ctx, p)
+		if err != nil {
+			return
+		}
+
+		var activeDeadlineSeconds int64 = 300
+		var completions int32 = 1
+		// failures are reported, and retried by a new job
+		var backoffLimit int32 = 0
+		enableServiceLinks := false
+
+		job = &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: jobname,
+			},
+			Spec: batchv1.JobSpec{
+				ActiveDeadlineSeconds: &activeDeadlineSeconds,
+				BackoffLimit:          &backoffLimit,
+				Completions:           &completions,
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: ctx.CommonLabels(),
+					},
+					Spec: v1.PodSpec{
+						EnableServiceLinks: &enableServiceLinks,
+						Containers: []v1.Container{
+							{
+								Name:    "abort-restore",
+								Image:   ctx.Image().Image(),
+								Command: []string{"sh", "-c", abortRestore},
+								Env: []v1.EnvVar{
+									{
+										Name:  "OLD",
+										Value: fmt.Sprintf("%d", p.Status.Version),
+									},
+								},
+								VolumeMounts: []v1.VolumeMount{
+									{
+										Name:      pvc.VolumeName,
+										MountPath: statefulset.DataVolumeMountPath,
+									},
+								},
+								// only use requests
+								Resources: v1.ResourceRequirements{
+									Requests: p.Spec.Resources.Requests,
+								},
+								SecurityContext: security.ContainerSecurityContext,
+							},
+						},
+						Volumes: []v1.Volume{
+							{
+								Name: pvc.VolumeName,
+								VolumeSource: v1.VolumeSource{
+									PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+										ClaimName: p.Status.VolumeStatuses[leaderIndex].ClaimName,
+									},
+								},
+							},
+						},
+						RestartPolicy:    v1.RestartPolicyNever,
+						SecurityContext:  security.DatabasePodSecurityContext,
+						ImagePullSecrets: p.Spec.ImagePullSecrets,
+						NodeSelector:     p.Spec.NodeSelector,
+						Tolerations:      p.Spec.Tolerations,
+					},
+				},
+			},
+		}
+
+		if err = ctx.SetMeta(job); err != nil {
+			return
+		}
+
+		err = ctx.Create(ctx, job)
+
+		return
+	}
+
+	if job.Status.Succeeded > 0 {
+		done = true
+	}
+
+	if err = cleanupJob(ctx, p, job); err != nil {
+		return
+	}
+
+	if job.Status.Failed > 0 {
+		err = errAbortRestoreJobFailed
+	}
+
+	return
+}
+
+// finishAbort brings the cluster back at its current version
+func finishAbort(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (err error) {
+	if err = configmap.RestoreDBId(ctx, p); err != nil {
+		return
+	}
+
+	if err = configmap.ClearUpgradeAnnotations(ctx, p); err != nil {
+		return
+	}
+
+	ctx.Eventf(v1.EventTypeWarning, "UpgradeAborted", "Upgrade to version %d aborted, cluster restored at version %d", p.Status.UpgradeVersion, p.Status.Version)
+
+	p.Status.UpgradeAbortedVersion = p.Spec.Version
+	p.Status.State = v1alpha1.PatroniPostgresStateScaling
+
+	clearUpgradeStatus(p)
+
+	return
+}
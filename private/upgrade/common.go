@@ -38,6 +38,7 @@ import (
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
 	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/certificate"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
 	"github.com/k-web-s/patroni-postgres-operator/private/security"
@@ -142,6 +143,8 @@ func ensureUpgradeJob(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, j Upgra
 		},
 	}
 
+	verifyServer(p, &job.Spec.Template.Spec)
+
 	j.CustomizePodSpec(&job.Spec.Template.Spec)
 
 	if err = ctx.SetMeta(job); err != nil {
@@ -155,8 +158,22 @@ func ensureUpgradeJob(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, j Upgra
 	return
 }
 
-// cleanupJob removes job if succeeded or failed (i.e. after a pod exited)
-func cleanupJob(ctx pcontext.Context, job *batchv1.Job) (err error) {
+// verifyServer makes upgrade helper connect with verify-full, once ssl is turned on
+func verifyServer(p *v1alpha1.PatroniPostgres, spec *v1.PodSpec) {
+	if certificate.Active(p) && p.Status.TLS != nil {
+		certificate.MountCA(spec, p.Status.TLS.SecretName, "")
+	}
+}
+
+// cleanupJob removes job if succeeded or failed (i.e. after a pod exited). Diagnostics of
+// failed jobs are saved before.
+func cleanupJob(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, job *batchv1.Job) (err error) {
+	if job.Status.Succeeded == 0 && job.Status.Failed > 0 && job.DeletionTimestamp == nil {
+		if err = saveDiagnostics(ctx, p, job); err != nil {
+			return
+		}
+	}
+
 	if job.Status.Succeeded+job.Status.Failed > 0 {
 		deletePropagationPolicy := metav1.DeletePropagationBackground
 
@@ -25,13 +25,37 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 
 package upgradecommon
 
+import (
+	"encoding/json"
+)
+
 const (
 	UpgradeMODEPre       = "preupgrade"
 	UpgradeMODEPreSync   = "preupgrade-sync"
 	UpgradeMODEPauseFlag = "pause"
 	UpgradeMODEPost      = "postuprgrade"
+
+	// PreparedXactsTimeoutFlag sets how long preupgrade waits for prepared transactions to finish
+	PreparedXactsTimeoutFlag = "prepared-xacts-timeout"
+)
+
+const (
+	// ResultVersion is the version of result documents written by upgrade jobs
+	ResultVersion = 1
+
+	// TerminationMessagePath is where upgrade jobs write their result document
+	TerminationMessagePath = "/dev/termination-log"
 )
 
+// Result is the document upgrade jobs write to their container's termination message
+type Result struct {
+	// Version of the document, must be ResultVersion
+	Version int `json:"version"`
+
+	// Result holds job specific result
+	Result json.RawMessage `json:"result"`
+}
+
 // preupgrade helper container will return this struct
 type Config struct {
 	Locale        string
@@ -39,5 +63,6 @@ type Config struct {
 	DataChecksums bool
 
 	// preupgrade check results
-	MaxPreparedTransactions int
+	// PreparedTransactions lists GIDs of prepared transactions not finished in time
+	PreparedTransactions []string
 }
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package upgrade
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/metrics"
+)
+
+const (
+	// diagnosticsKeyTimeFormat is used as prefix of diagnostics keys, thus keys sort by time
+	diagnosticsKeyTimeFormat = "20060102T150405Z"
+
+	// diagnosticsMaxEntries limits number of entries kept, to keep the ConfigMap small
+	diagnosticsMaxEntries = 16
+
+	// diagnosticsLogLimitBytes limits size of captured logs per entry
+	diagnosticsLogLimitBytes = 16 * 1024
+)
+
+var (
+	diagnosticsRetention = flag.Duration("upgrade-diagnostics-retention", 7*24*time.Hour, "How long diagnostics of failed upgrade jobs are kept")
+	diagnosticsLogLines  = flag.Int64("upgrade-diagnostics-log-lines", 50, "Number of log lines captured from failed upgrade jobs")
+)
+
+// DiagnosticsConfigMapName returns name of ConfigMap holding diagnostics of failed upgrade jobs
+func DiagnosticsConfigMapName(p *v1alpha1.PatroniPostgres) string {
+	return fmt.Sprintf("%s-upgrade-diagnostics", p.Name)
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+// saveDiagnostics records step, exit code and logs tail of a failed job in diagnostics ConfigMap,
+// and in a Warning Event
+func saveDiagnostics(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, job *batchv1.Job) (err error) {
+	now := time.Now().UTC()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "step: %s\njob: %s\n", p.Status.State, job.Name)
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == v1.ConditionTrue {
+			fmt.Fprintf(&b, "reason: %s: %s\n", condition.Reason, condition.Message)
+		}
+	}
+
+	exitCode, err := describeFailedPod(ctx, job, &b)
+	if err != nil {
+		return
+	}
+
+	cm := &v1.ConfigMap{}
+	create := false
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: DiagnosticsConfigMapName(p)}, cm)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return
+		}
+
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: DiagnosticsConfigMapName(p),
+			},
+		}
+		create = true
+	}
+
+	if err = ctx.SetMeta(cm); err != nil {
+		return
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+
+	cm.Data[fmt.Sprintf("%s-%s", now.Format(diagnosticsKeyTimeFormat), job.Name)] = b.String()
+	pruneDiagnostics(cm, now)
+
+	if create {
+		err = ctx.Create(ctx, cm)
+	} else {
+		err = ctx.Update(ctx, cm)
+	}
+	if err != nil {
+		return
+	}
+
+	metrics.UpgradeJobFailed(p, p.Status.State)
+
+	ctx.Eventf(v1.EventTypeWarning, "UpgradeJobFailed", "Step %s: job %s failed with exit code %d, see ConfigMap %s", p.Status.State, job.Name, exitCode, cm.Name)
+
+	return
+}
+
+// describeFailedPod writes exit code and logs tail of job's last failed container to b
+func describeFailedPod(ctx pcontext.Context, job *batchv1.Job, b *strings.Builder) (exitCode int32, err error) {
+	var ls labels.Selector
+	if ls, err = metav1.LabelSelectorAsSelector(job.Spec.Selector); err != nil {
+		return
+	}
+
+	var pods v1.PodList
+	if err = ctx.List(ctx, &pods, &client.ListOptions{Namespace: job.Namespace, LabelSelector: ls}); err != nil {
+		return
+	}
+
+	var pod *v1.Pod
+	var container string
+	var terminated *v1.ContainerStateTerminated
+	var previous bool
+
+	for idx := range pods.Items {
+		for _, cs := range slices.Concat(pods.Items[idx].Status.InitContainerStatuses, pods.Items[idx].Status.ContainerStatuses) {
+			t, prev := cs.State.Terminated, false
+			if t == nil || t.ExitCode == 0 {
+				// restarted container
+				t, prev = cs.LastTerminationState.Terminated, true
+			}
+
+			if t == nil || t.ExitCode == 0 {
+				continue
+			}
+
+			if terminated == nil || t.FinishedAt.After(terminated.FinishedAt.Time) {
+				pod, container, terminated, previous = &pods.Items[idx], cs.Name, t, prev
+			}
+		}
+	}
+
+	if pod == nil {
+		b.WriteString("no failed container found\n")
+		return
+	}
+
+	exitCode = terminated.ExitCode
+	fmt.Fprintf(b, "pod: %s\ncontainer: %s\nexitCode: %d\n", pod.Name, container, exitCode)
+	if terminated.Reason != "" {
+		fmt.Fprintf(b, "terminationReason: %s\n", terminated.Reason)
+	}
+
+	limitBytes := int64(diagnosticsLogLimitBytes)
+	request := ctx.Clientset().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		TailLines:  diagnosticsLogLines,
+		LimitBytes: &limitBytes,
+	})
+
+	logs, lerr := request.Stream(ctx)
+	if lerr != nil {
+		// logs may be gone already, keep other details
+		fmt.Fprintf(b, "logs: unavailable: %v\n", lerr)
+		return
+	}
+	defer logs.Close()
+
+	b.WriteString("logs:\n")
+	_, err = io.Copy(b, io.LimitReader(logs, diagnosticsLogLimitBytes))
+
+	return
+}
+
+// pruneDiagnostics removes entries older than retention, and oldest entries above limit
+func pruneDiagnostics(cm *v1.ConfigMap, now time.Time) {
+	var keys []string
+
+	for key := range cm.Data {
+		ts, err := time.Parse(diagnosticsKeyTimeFormat, strings.SplitN(key, "-", 2)[0])
+		if err != nil || now.Sub(ts) > *diagnosticsRetention {
+			delete(cm.Data, key)
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for len(keys) > diagnosticsMaxEntries {
+		delete(cm.Data, keys[0])
+		keys = keys[1:]
+	}
+}
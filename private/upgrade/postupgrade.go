@@ -71,7 +71,7 @@ func (postupgradeHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgr
 		done = true
 	}
 
-	if err = cleanupJob(ctx, job); err != nil {
+	if err = cleanupJob(ctx, p, job); err != nil {
 		return
 	}
 
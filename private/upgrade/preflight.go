@@ -0,0 +1,302 @@
+/*
+Copyright 2026 Richard Kojedzinszky
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+  1. Redistributions of source code must retain the above copyright notice, this
+     list of conditions and the following disclaimer.
+
+  2. Redistributions in binary form must reproduce the above copyright notice,
+     this list of conditions and the following disclaimer in the documentation
+     and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS “AS IS”
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package upgrade
+
+import (
+	_ "embed"
+	"flag"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
+	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/secret"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/service"
+	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/jobresult"
+	"github.com/k-web-s/patroni-postgres-operator/private/security"
+)
+
+const (
+	preflightWorkVolume    = "work"
+	preflightWorkMountPath = "/work"
+)
+
+var (
+	//go:embed upgrade-scripts/preflight
+	preflightScript string
+
+	minFreePercent = flag.Int("upgrade-min-free-percent", 10, "Minimum free space on volumes after an upgrade, in percent of volume size")
+)
+
+type preflightHandler struct {
+}
+
+func (preflightHandler) name() v1alpha1.PatroniPostgresState {
+	return v1alpha1.PatroniPostgresStateUpgradePreflight
+}
+
+// preflightResult is output by preflight job
+type preflightResult struct {
+	// Errors lists failed checks
+	Errors []string `json:"errors"`
+
+	// DataBytes holds the size of the primary's data
+	DataBytes int64 `json:"dataBytes"`
+
+	// NewClusterBytes holds the size of an empty cluster of the new version
+	NewClusterBytes int64 `json:"newClusterBytes"`
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;delete
+
+func (preflightHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (done bool, err error) {
+	job := &batchv1.Job{}
+	jobname := fmt.Sprintf("%s-preflight", p.Name)
+
+	err = ctx.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: jobname}, job)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return
+		}
+
+		return false, createPreflightJob(ctx, p, jobname)
+	}
+
+	var failures []string
+
+	if job.Status.Succeeded > 0 {
+		var result preflightResult
+		if err = jobresult.Get(ctx, job, &result); err != nil {
+			return
+		}
+
+		failures = result.Errors
+		if msg := checkHeadroom(p, &result); msg != "" {
+			failures = append(failures, msg)
+		}
+	} else if job.Status.Failed > 0 {
+		failures = append(failures, fmt.Sprintf("preflight job failed, see ConfigMap %s", DiagnosticsConfigMapName(p)))
+	}
+
+	if err = cleanupJob(ctx, p, job); err != nil {
+		return
+	}
+
+	if len(failures) > 0 {
+		err = cancelUpgrade(ctx, p, strings.Join(failures, "; "))
+	} else if job.Status.Succeeded > 0 {
+		p.Status.UpgradePreflightError = ""
+
+		done = true
+	}
+
+	return
+}
+
+// checkHeadroom checks whether volumes have enough space for the upgrade
+func checkHeadroom(p *v1alpha1.PatroniPostgres, result *preflightResult) string {
+	capacity := p.Spec.VolumeSize.Value()
+	for _, vs := range p.Status.VolumeStatuses {
+		if c := vs.Capacity.Value(); c > 0 && c < capacity {
+			capacity = c
+		}
+	}
+
+	free := capacity - result.DataBytes - result.NewClusterBytes
+	if free*100 < capacity*int64(*minFreePercent) {
+		return fmt.Sprintf("not enough space: volume size %s, data %s, new cluster %s, at least %d%% free space required",
+			resource.NewQuantity(capacity, resource.BinarySI),
+			resource.NewQuantity(result.DataBytes, resource.BinarySI),
+			resource.NewQuantity(result.NewClusterBytes, resource.BinarySI),
+			*minFreePercent,
+		)
+	}
+
+	return ""
+}
+
+// cancelUpgrade stops the upgrade before any downtime, reporting reason in status
+func cancelUpgrade(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, reason string) (err error) {
+	if err = configmap.ClearUpgradeAnnotations(ctx, p); err != nil {
+		return
+	}
+
+	ctx.Eventf(v1.EventTypeWarning, "UpgradePreflightFailed", "Upgrade to version %d cancelled: %s", p.Status.UpgradeVersion, reason)
+
+	p.Status.UpgradePreflightError = reason
+	p.Status.UpgradeAbortedVersion = p.Spec.Version
+	p.Status.State = v1alpha1.PatroniPostgresStateReady
+
+	clearUpgradeStatus(p)
+
+	return
+}
+
+func createPreflightJob(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, jobname string) (err error) {
+	initdbArgs, err := configmap.GetPrimaryInitdbArgs(ctx, p)
+	if err != nil {
+		return
+	}
+
+	targetImage := ctx.Catalog().GetImage(p.Status.UpgradeVersion)
+	if targetImage == nil {
+		return fmt.Errorf("unsupported version: %d", p.Status.UpgradeVersion)
+	}
+
+	var activeDeadlineSeconds int64 = 3600
+	var backoffLimit int32 = 0
+	enableServiceLinks := false
+
+	workMount := v1.VolumeMount{
+		Name:      preflightWorkVolume,
+		MountPath: preflightWorkMountPath,
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: jobname,
+		},
+		Spec: batchv1.JobSpec{
+			ActiveDeadlineSeconds: &activeDeadlineSeconds,
+			BackoffLimit:          &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: ctx.CommonLabels(),
+				},
+				Spec: v1.PodSpec{
+					EnableServiceLinks: &enableServiceLinks,
+					InitContainers: []v1.Container{
+						{
+							// lists extensions available in the image running the new version
+							Name:  "target-extensions",
+							Image: targetImage.Image(),
+							Command: []string{
+								"sh",
+								"-c",
+								fmt.Sprintf("ls /usr/share/postgresql/%d/extension/ | sed -n -e 's/\\.control$$//p' > %s/target-extensions", p.Status.UpgradeVersion, preflightWorkMountPath),
+							},
+							VolumeMounts:    []v1.VolumeMount{workMount},
+							SecurityContext: security.ContainerSecurityContext,
+						},
+					},
+					Containers: []v1.Container{
+						{
+							Name:    "preflight",
+							Image:   ctx.Image().Image(),
+							Command: []string{"sh", "-c", preflightScript},
+							Env: []v1.EnvVar{
+								{
+									Name:  "OLD",
+									Value: fmt.Sprintf("%d", p.Status.Version),
+								},
+								{
+									Name:  "NEW",
+									Value: fmt.Sprintf("%d", p.Status.UpgradeVersion),
+								},
+								{
+									Name:  "INITDB_ARGS",
+									Value: initdbArgs,
+								},
+								{
+									Name:  "PGHOST",
+									Value: p.Name,
+								},
+								{
+									Name:  "PGPORT",
+									Value: fmt.Sprintf("%d", service.PostgresPort),
+								},
+								{
+									Name:  "PGUSER",
+									Value: statefulset.PatroniReplicationUsername,
+								},
+								{
+									Name: "PGPASSWORD",
+									ValueFrom: &v1.EnvVarSource{
+										SecretKeyRef: &v1.SecretKeySelector{
+											LocalObjectReference: v1.LocalObjectReference{
+												Name: secret.Name(p),
+											},
+											Key: secret.ReplicationUserPasswordKey,
+										},
+									},
+								},
+								{
+									Name:  "SUPERUSER",
+									Value: statefulset.PatroniSuperuserUsername,
+								},
+								{
+									Name: "SUPERUSER_PASSWORD",
+									ValueFrom: &v1.EnvVarSource{
+										SecretKeyRef: &v1.SecretKeySelector{
+											LocalObjectReference: v1.LocalObjectReference{
+												Name: secret.Name(p),
+											},
+											Key: secret.SuperUserPasswordKey,
+										},
+									},
+								},
+							},
+							VolumeMounts: []v1.VolumeMount{workMount},
+							// only use requests
+							Resources: v1.ResourceRequirements{
+								Requests: p.Spec.Resources.Requests,
+							},
+							SecurityContext: security.ContainerSecurityContext,
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: preflightWorkVolume,
+							VolumeSource: v1.VolumeSource{
+								EmptyDir: &v1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					RestartPolicy:    v1.RestartPolicyNever,
+					SecurityContext:  security.DatabasePodSecurityContext,
+					ImagePullSecrets: p.Spec.ImagePullSecrets,
+					NodeSelector:     p.Spec.NodeSelector,
+					Tolerations:      p.Spec.Tolerations,
+				},
+			},
+		},
+	}
+
+	if err = ctx.SetMeta(job); err != nil {
+		return
+	}
+
+	return ctx.Create(ctx, job)
+}
@@ -80,7 +80,7 @@ func (preupgradeSyncHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPos
 		done = true
 	}
 
-	if err = cleanupJob(ctx, job); err != nil {
+	if err = cleanupJob(ctx, p, job); err != nil {
 		return
 	}
 
@@ -26,27 +26,26 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package upgrade
 
 import (
-	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"strings"
+	"time"
 
-	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
 	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/service"
+	"github.com/k-web-s/patroni-postgres-operator/private/jobresult"
 	upgradecommon "github.com/k-web-s/patroni-postgres-operator/private/upgrade/common"
 )
 
 var (
-	errPreupgradeJobFailed            = fmt.Errorf("preupgrade job failed")
-	errMaxPreparedTransactionsNotZero = fmt.Errorf("max_prepared_transactions not zero, cannot continue")
+	errPreupgradeJobFailed         = fmt.Errorf("preupgrade job failed")
+	errPreparedTransactionsPending = fmt.Errorf("prepared transactions pending")
+
+	preparedXactsTimeout = flag.Duration("upgrade-prepared-xacts-timeout", 5*time.Minute, "Time to wait for prepared transactions to finish before upgrading")
 )
 
 type preupgradeHandler struct {
@@ -67,12 +66,12 @@ func (preupgradeHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgre
 
 	if job.Status.Succeeded > 0 {
 		var config upgradecommon.Config
-		if err = getInitdbArgsFromJob(ctx, job, &config); err != nil {
+		if err = jobresult.Get(ctx, job, &config); err != nil {
 			return
 		}
 
-		if config.MaxPreparedTransactions != 0 {
-			err2 = errMaxPreparedTransactionsNotZero
+		if len(config.PreparedTransactions) > 0 {
+			err2 = fmt.Errorf("%w: %s", errPreparedTransactionsPending, strings.Join(config.PreparedTransactions, ", "))
 		} else {
 			if err = configmap.SetPrimaryInitdbArgs(ctx, p, parseConfigToInitdbArgs(&config)); err != nil {
 				return
@@ -82,7 +81,7 @@ func (preupgradeHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgre
 		}
 	}
 
-	if err = cleanupJob(ctx, job); err != nil {
+	if err = cleanupJob(ctx, p, job); err != nil {
 		return
 	}
 
@@ -97,48 +96,6 @@ func (preupgradeHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgre
 	return
 }
 
-// +kubebuilder:rbac:groups="",resources=pods,verbs=list
-// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
-
-func getInitdbArgsFromJob(ctx pcontext.Context, job *batchv1.Job, config *upgradecommon.Config) (err error) {
-	var ls labels.Selector
-	if ls, err = metav1.LabelSelectorAsSelector(job.Spec.Selector); err != nil {
-		return
-	}
-
-	var pods v1.PodList
-	if err = ctx.List(ctx, &pods, &client.ListOptions{Namespace: job.Namespace, LabelSelector: ls}); err != nil {
-		return
-	}
-
-	if len(pods.Items) == 0 {
-		err = fmt.Errorf("no pod found for preprocess job")
-		return
-	}
-
-	pod := &pods.Items[0]
-
-	var tailLines int64 = 1
-	request := ctx.Clientset().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
-		TailLines: &tailLines,
-	})
-	var logs io.ReadCloser
-	if logs, err = request.Stream(ctx); err != nil {
-		return
-	}
-	defer logs.Close()
-	buf := make([]byte, 2048)
-	n, _ := logs.Read(buf)
-	if n == 0 {
-		err = fmt.Errorf("short read from pod logs")
-		return
-	}
-
-	err = json.Unmarshal(buf[:n], config)
-
-	return
-}
-
 func parseConfigToInitdbArgs(config *upgradecommon.Config) string {
 	var argsa []string
 	if config.Locale != "" {
@@ -160,7 +117,7 @@ type preupgradeJob struct {
 
 // ActiveDeadlineSeconds implements UpgradeJob.
 func (preupgradeJob) ActiveDeadlineSeconds() int64 {
-	return 300
+	return 300 + int64(preparedXactsTimeout.Seconds())
 }
 
 // DBPort implements UpgradeJob.
@@ -174,7 +131,11 @@ func (preupgradeJob) Mode() string {
 }
 
 // CustomizePodSpec implements UpgradeJob.
-func (preupgradeJob) CustomizePodSpec(*v1.PodSpec) {
+func (preupgradeJob) CustomizePodSpec(ps *v1.PodSpec) {
+	ps.Containers[0].Env = append(ps.Containers[0].Env, v1.EnvVar{
+		Name:  strings.ToUpper(strings.ReplaceAll(upgradecommon.PreparedXactsTimeoutFlag, "-", "_")),
+		Value: preparedXactsTimeout.String(),
+	})
 }
 
 var _ UpgradeJob = preupgradeJob{}
@@ -34,7 +34,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
 	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
@@ -146,12 +145,8 @@ func (primaryUpgradeMoveHandler) handle(ctx pcontext.Context, p *v1alpha1.Patron
 	}
 
 	// cleanup job in case of success/fail
-	if job.Status.Succeeded+job.Status.Failed > 0 {
-		deletePropagationPolicy := metav1.DeletePropagationBackground
-
-		if err = ctx.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &deletePropagationPolicy}); err != nil {
-			return
-		}
+	if err = cleanupJob(ctx, p, job); err != nil {
+		return
 	}
 
 	// handle failed case
@@ -27,23 +27,20 @@ package upgrade
 
 import (
 	_ "embed"
-	"encoding/json"
 	"fmt"
-	"io"
 
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
 	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/pvc"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/statefulset"
+	"github.com/k-web-s/patroni-postgres-operator/private/jobresult"
 	"github.com/k-web-s/patroni-postgres-operator/private/security"
 )
 
@@ -172,7 +169,7 @@ func (primaryUpgradeHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPos
 	// handle success
 	if job.Status.Succeeded > 0 {
 		var result upgradeJobResult
-		if result, err = getResultFromPrimaryUpgradeJob(ctx, job); err != nil {
+		if err = jobresult.Get(ctx, job, &result); err != nil {
 			return
 		}
 
@@ -190,12 +187,8 @@ func (primaryUpgradeHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniPos
 	}
 
 	// cleanup job in case of success/fail
-	if job.Status.Succeeded+job.Status.Failed > 0 {
-		deletePropagationPolicy := metav1.DeletePropagationBackground
-
-		if err = ctx.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &deletePropagationPolicy}); err != nil {
-			return
-		}
+	if err = cleanupJob(ctx, p, job); err != nil {
+		return
 	}
 
 	// handle failed case
@@ -210,45 +203,3 @@ type upgradeJobResult struct {
 	OldLatestCheckpointLocation string `json:"oldLatestCheckpointLocation"`
 	NewDatabaseSystemIdentifier string `json:"newDatabaseSystemIdentifier"`
 }
-
-// +kubebuilder:rbac:groups="",resources=pods,verbs=list
-// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
-
-func getResultFromPrimaryUpgradeJob(ctx pcontext.Context, job *batchv1.Job) (result upgradeJobResult, err error) {
-	var ls labels.Selector
-	if ls, err = metav1.LabelSelectorAsSelector(job.Spec.Selector); err != nil {
-		return
-	}
-
-	var pods v1.PodList
-	if err = ctx.List(ctx, &pods, &client.ListOptions{Namespace: job.Namespace, LabelSelector: ls}); err != nil {
-		return
-	}
-
-	if len(pods.Items) == 0 {
-		err = fmt.Errorf("no pod found for upgrade job")
-		return
-	}
-
-	pod := &pods.Items[0]
-
-	var tailLines int64 = 1
-	request := ctx.Clientset().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
-		TailLines: &tailLines,
-	})
-	var logs io.ReadCloser
-	if logs, err = request.Stream(ctx); err != nil {
-		return
-	}
-	defer logs.Close()
-	buf := make([]byte, 2048)
-	n, _ := logs.Read(buf)
-	if n == 0 {
-		err = fmt.Errorf("short read from pod logs")
-		return
-	}
-
-	err = json.Unmarshal(buf[:n], &result)
-
-	return
-}
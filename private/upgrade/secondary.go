@@ -90,6 +90,14 @@ func (secondaryUpgradeHandler) handle(ctx pcontext.Context, p *v1alpha1.PatroniP
 		} else if job.Status.Failed > 0 {
 			failed += 1
 
+			if job.DeletionTimestamp != nil {
+				continue
+			}
+
+			if err = saveDiagnostics(ctx, p, job); err != nil {
+				return
+			}
+
 			if err = ctx.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &deletePropagationPolicy}); err != nil {
 				return
 			}
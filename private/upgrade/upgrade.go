@@ -27,14 +27,21 @@ package upgrade
 
 import (
 	_ "embed"
+	"errors"
 	"flag"
+	"slices"
 	"strings"
+	"time"
 
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/k-web-s/patroni-postgres-operator/api/v1alpha1"
 	pcontext "github.com/k-web-s/patroni-postgres-operator/private/context"
 	"github.com/k-web-s/patroni-postgres-operator/private/controllers/configmap"
+	"github.com/k-web-s/patroni-postgres-operator/private/metrics"
 )
 
 var (
@@ -54,10 +61,21 @@ type upgradehandler interface {
 type upgradestep struct {
 	handler upgradehandler
 	next    upgradehandler
+
+	// finish is called when the last step is done
+	finish func(pcontext.Context, *v1alpha1.PatroniPostgres) error
 }
 
 var (
 	upgrademap = map[v1alpha1.PatroniPostgresState]*upgradestep{}
+
+	// jobFailures lists errors counted as upgrade failures
+	jobFailures = []error{
+		errPreupgradeJobFailed,
+		errPreparedTransactionsPending,
+		errPreupgradeSyncJobFailed,
+		errPrimaryUpgradeJobFailed,
+	}
 )
 
 func init() {
@@ -66,10 +84,12 @@ func init() {
 	primaryStream = strings.ReplaceAll(primaryStream, "$", "$$")
 	secondaryUpgrade = strings.ReplaceAll(secondaryUpgrade, "$", "$$")
 	primaryUpgradeMove = strings.ReplaceAll(primaryUpgradeMove, "$", "$$")
+	abortRestore = strings.ReplaceAll(abortRestore, "$", "$$")
+	preflightScript = strings.ReplaceAll(preflightScript, "$", "$$")
 
-	var step *upgradestep
-	for _, handler := range []upgradehandler{
+	register(finishUpgrade,
 		preupgradeHandler{},
+		preflightHandler{},
 		preupgradeScaledownHandler{},
 		preupgradeSyncHandler{},
 		scaledownHandler{},
@@ -77,13 +97,25 @@ func init() {
 		secondaryUpgradeHandler{},
 		primaryUpgradeMoveHandler{},
 		postupgradeHandler{},
-	} {
+	)
+
+	register(finishAbort,
+		abortScaledownHandler{},
+		abortRestoreHandler{},
+	)
+}
+
+// register chains handlers, finish is called after the last one
+func register(finish func(pcontext.Context, *v1alpha1.PatroniPostgres) error, handlers ...upgradehandler) {
+	var step *upgradestep
+	for _, handler := range handlers {
 		if step != nil {
 			step.next = handler
 		}
 
 		step = &upgradestep{
 			handler: handler,
+			finish:  finish,
 		}
 
 		upgrademap[step.handler.name()] = step
@@ -91,26 +123,44 @@ func init() {
 }
 
 func Handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (ret ctrl.Result, err error) {
+	if abortRequested(p) && !aborting(p) {
+		if abortable(p) {
+			ctx.Eventf(v1.EventTypeWarning, "UpgradeAborting", "Aborting upgrade to version %d in step %s", p.Status.UpgradeVersion, p.Status.State)
+
+			startStep(ctx, p, v1alpha1.PatroniPostgresStateUpgradeAbortScaleDown)
+			ret.Requeue = true
+			return
+		}
+
+		log.FromContext(ctx).Info("upgrade cannot be aborted", "state", p.Status.State)
+	}
+
 	handler, ok := upgrademap[p.Status.State]
 	if !ok {
-		p.Status.State = v1alpha1.PatroniPostgresStateUpgradePreupgrade
+		ctx.Eventf(v1.EventTypeNormal, "UpgradeStarted", "Upgrading from version %d to %d", p.Status.Version, p.Status.UpgradeVersion)
+
+		startStep(ctx, p, v1alpha1.PatroniPostgresStateUpgradePreupgrade)
 		ret.Requeue = true
 		return
 	}
 
 	done, err := handler.handler.handle(ctx, p)
 	if err != nil {
+		// persisted along with conditions
+		if abortable(p) && slices.ContainsFunc(jobFailures, func(e error) bool { return errors.Is(err, e) }) {
+			p.Status.UpgradeFailures++
+		}
+
 		return
 	}
 
 	if done {
+		finishStep(ctx, p)
+
 		if handler.next != nil {
-			p.Status.State = handler.next.name()
+			startStep(ctx, p, handler.next.name())
 		} else {
-			p.Status.State = v1alpha1.PatroniPostgresStateReady
-			p.Status.UpgradeVersion = 0
-
-			err = configmap.ClearUpgradeAnnotations(ctx, p)
+			err = handler.finish(ctx, p)
 		}
 
 		ret.Requeue = true
@@ -118,3 +168,68 @@ func Handle(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) (ret ctrl.Result,
 
 	return
 }
+
+// finishUpgrade continues with the next upgrade in upgrade path, or finishes the upgrade
+func finishUpgrade(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) error {
+	if next := nextHop(p); next != 0 {
+		// continue with next upgrade
+		ctx.Eventf(v1.EventTypeNormal, "UpgradeStarted", "Upgrading from version %d to %d", p.Status.Version, next)
+
+		p.Status.UpgradeVersion = next
+		p.Status.UpgradeFailures = 0
+		startStep(ctx, p, v1alpha1.PatroniPostgresStateUpgradePreupgrade)
+	} else {
+		ctx.Eventf(v1.EventTypeNormal, "UpgradeFinished", "Upgraded to version %d", p.Status.Version)
+
+		p.Status.State = v1alpha1.PatroniPostgresStateReady
+
+		clearUpgradeStatus(p)
+
+		// backups of the previous version cannot be continued with new WAL
+		if p.Status.Backup != nil {
+			p.Status.Backup.NextBackup = nil
+		}
+	}
+
+	return configmap.ClearUpgradeAnnotations(ctx, p)
+}
+
+// clearUpgradeStatus clears status of the upgrade in progress
+func clearUpgradeStatus(p *v1alpha1.PatroniPostgres) {
+	p.Status.UpgradeVersion = 0
+	p.Status.UpgradePath = nil
+	p.Status.UpgradeFailures = 0
+	p.Status.UpgradeStepStartTime = nil
+}
+
+// startStep moves the upgrade to given step, recording its start time
+func startStep(ctx pcontext.Context, p *v1alpha1.PatroniPostgres, state v1alpha1.PatroniPostgresState) {
+	now := metav1.Now()
+
+	p.Status.State = state
+	p.Status.UpgradeStepStartTime = &now
+
+	ctx.Eventf(v1.EventTypeNormal, "UpgradeStepStarted", "Upgrade to version %d: step %s started", p.Status.UpgradeVersion, state)
+}
+
+// finishStep reports the current upgrade step done, along with its duration
+func finishStep(ctx pcontext.Context, p *v1alpha1.PatroniPostgres) {
+	var took time.Duration
+	if p.Status.UpgradeStepStartTime != nil {
+		took = time.Since(p.Status.UpgradeStepStartTime.Time)
+
+		metrics.ObserveUpgradeStep(p, p.Status.State, took.Seconds())
+	}
+
+	ctx.Eventf(v1.EventTypeNormal, "UpgradeStepFinished", "Upgrade to version %d: step %s finished in %s", p.Status.UpgradeVersion, p.Status.State, took.Round(time.Second))
+}
+
+// nextHop returns the version following the current version in the upgrade path, 0 if there is none
+func nextHop(p *v1alpha1.PatroniPostgres) int {
+	idx := slices.Index(p.Status.UpgradePath, p.Status.Version)
+	if idx == -1 || idx+1 == len(p.Status.UpgradePath) {
+		return 0
+	}
+
+	return p.Status.UpgradePath[idx+1]
+}